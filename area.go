@@ -0,0 +1,105 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// Area is a view of an area of the common memory carried in a frame.
+// Address is the word address of the top of Data, and Data holds 2 bytes per word.
+type Area struct {
+	Address uint16
+	Data    []byte
+}
+
+// Words returns the size of the area in words.
+func (a Area) Words() int {
+	return len(a.Data) / 2
+}
+
+// Contains reports whether the word address is in the area.
+func (a Area) Contains(addr uint16) bool {
+	return addr >= a.Address && int(addr-a.Address) < a.Words()
+}
+
+// Word returns the word at the word address of the common memory.
+func (a Area) Word(addr uint16) (uint16, error) {
+	if !a.Contains(addr) {
+		return 0, errors.Wrapf(ErrOutOfArea, "word address %d not in %d-%d", addr, a.Address, int(a.Address)+a.Words()-1)
+	}
+	offset := int(addr-a.Address) * 2
+	return binary.BigEndian.Uint16(a.Data[offset : offset+2]), nil
+}
+
+// Bit returns the bit of the word at the word address of the common memory.
+// bit is numbered from 0 for the least significant bit to 15.
+func (a Area) Bit(addr uint16, bit uint) (bool, error) {
+	if bit > 15 {
+		return false, errors.Wrapf(ErrOutOfArea, "bit %d not in 0-15", bit)
+	}
+	w, err := a.Word(addr)
+	if err != nil {
+		return false, err
+	}
+	return w&(1<<bit) != 0, nil
+}
+
+// areas returns Area1 and Area2 in data described by the header.
+// Area1 is C_SZ1 words at C_AD1, followed by Area2 of C_SZ2 words at C_AD2,
+// and the areas are truncated to data.
+func areas(h *FALinkHeader, data []byte) (Area, Area) {
+	n1 := minInt(int(h.CSZ1)*2, len(data))
+	n2 := minInt(n1+int(h.CSZ2)*2, len(data))
+	return Area{Address: h.CAD1, Data: data[:n1:n1]}, Area{Address: h.CAD2, Data: data[n1:n2:n2]}
+}
+
+// Area1 returns Area1, the bit area, in the frame.
+// Data is a part of c.Data, which is C_SZ1 words at C_AD1.
+func (c *Cyclic) Area1() Area {
+	a1, _ := areas(c.Header, c.Data)
+	return a1
+}
+
+// Area2 returns Area2, the word area, in the frame.
+// Data is a part of c.Data, which is C_SZ2 words at C_AD2 following Area1.
+func (c *Cyclic) Area2() Area {
+	_, a2 := areas(c.Header, c.Data)
+	return a2
+}
+
+// Area1Bit returns the bit of the word at the word address in Area1.
+func (c *Cyclic) Area1Bit(addr uint16, bit uint) (bool, error) {
+	return c.Area1().Bit(addr, bit)
+}
+
+// Area2Word returns the word at the word address in Area2.
+func (c *Cyclic) Area2Word(addr uint16) (uint16, error) {
+	return c.Area2().Word(addr)
+}
+
+// Area1 returns Area1, the bit area, in the cyclic data carried with the token.
+func (t *Token) Area1() Area {
+	a1, _ := areas(t.Header, t.Data)
+	return a1
+}
+
+// Area2 returns Area2, the word area, in the cyclic data carried with the token.
+func (t *Token) Area2() Area {
+	_, a2 := areas(t.Header, t.Data)
+	return a2
+}
+
+// Area1Bit returns the bit of the word at the word address in Area1.
+func (t *Token) Area1Bit(addr uint16, bit uint) (bool, error) {
+	return t.Area1().Bit(addr, bit)
+}
+
+// Area2Word returns the word at the word address in Area2.
+func (t *Token) Area2Word(addr uint16) (uint16, error) {
+	return t.Area2().Word(addr)
+}
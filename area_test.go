@@ -0,0 +1,96 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestCyclicAreas(t *testing.T) {
+	data := []byte{
+		0x00, 0x01, 0x80, 0x00, // Area1: 2 words at 4
+		0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, // Area2: 3 words at 64
+	}
+	c := flnet.NewCyclic(1, 255, 0, 4, 2, 64, 3, &data)
+
+	if diff := cmp.Diff(flnet.Area{Address: 4, Data: data[:4]}, c.Area1()); diff != "" {
+		t.Errorf("Area1 differs: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff(flnet.Area{Address: 64, Data: data[4:]}, c.Area2()); diff != "" {
+		t.Errorf("Area2 differs: (-want +got)\n%s", diff)
+	}
+
+	bits := []struct {
+		addr uint16
+		bit  uint
+		want bool
+	}{
+		{4, 0, true},
+		{4, 1, false},
+		{5, 15, true},
+		{5, 14, false},
+	}
+	for _, b := range bits {
+		got, err := c.Area1Bit(b.addr, b.bit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != b.want {
+			t.Errorf("Area1Bit(%d, %d): got %v, want %v", b.addr, b.bit, got, b.want)
+		}
+	}
+
+	words := []struct {
+		addr uint16
+		want uint16
+	}{
+		{64, 0x1234},
+		{65, 0x5678},
+		{66, 0x9abc},
+	}
+	for _, w := range words {
+		got, err := c.Area2Word(w.addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w.want {
+			t.Errorf("Area2Word(%d): got %#04x, want %#04x", w.addr, got, w.want)
+		}
+	}
+
+	errs := []struct {
+		description string
+		err         error
+	}{
+		{"Area1 below", func() error { _, err := c.Area1Bit(3, 0); return err }()},
+		{"Area1 above", func() error { _, err := c.Area1Bit(6, 0); return err }()},
+		{"Area1 bit 16", func() error { _, err := c.Area1Bit(4, 16); return err }()},
+		{"Area2 above", func() error { _, err := c.Area2Word(67); return err }()},
+	}
+	for _, e := range errs {
+		if !errors.Is(e.err, flnet.ErrOutOfArea) {
+			t.Errorf("%s: got %v, want %v", e.description, e.err, flnet.ErrOutOfArea)
+		}
+	}
+}
+
+func TestCyclicAreasShortData(t *testing.T) {
+	// The frame without data declares the areas but carries no words.
+	c := flnet.NewCyclic(1, 255, 0, 4, 4, 64, 64, nil)
+
+	if got, want := c.Area1().Words(), 0; got != want {
+		t.Errorf("Area1 words: got %d, want %d", got, want)
+	}
+	if got, want := c.Area2().Words(), 0; got != want {
+		t.Errorf("Area2 words: got %d, want %d", got, want)
+	}
+	if _, err := c.Area2Word(64); !errors.Is(err, flnet.ErrOutOfArea) {
+		t.Errorf("got %v, want %v", err, flnet.ErrOutOfArea)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// UDP port definitions of FL-net.
+const (
+	PortCyclic        = 55000
+	PortMessage       = 55001
+	PortParticipation = 55002
+	PortTrigger       = 55003
+)
+
+// Ports is the set of the UDP ports used by a Conn.
+type Ports struct {
+	// Cyclic is the port of the token and cyclic frames.
+	Cyclic int
+	// Message is the port of the message frames.
+	Message int
+	// Participation is the port of the participation request frames.
+	Participation int
+	// Trigger is the port of the trigger frames.
+	Trigger int
+}
+
+// DefaultPorts is the standard UDP ports of FL-net.
+var DefaultPorts = Ports{
+	Cyclic:        PortCyclic,
+	Message:       PortMessage,
+	Participation: PortParticipation,
+	Trigger:       PortTrigger,
+}
+
+// list returns the ports in the order of the sockets of a Conn.
+func (p Ports) list() []int {
+	return []int{p.Cyclic, p.Message, p.Participation, p.Trigger}
+}
+
+// socket returns the index in Ports.list of the port for the frames with the TCD.
+func socket(tcd uint16) int {
+	switch tcd {
+	case TCDToken, TCDCyclic:
+		return 0
+	case TCDParticipationRequest:
+		return 2
+	case TCDTrigger:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Packet is a frame received by a Conn.
+type Packet struct {
+	Frame FLnet
+	// Addr is the address which the frame is sent from.
+	Addr *net.UDPAddr
+}
+
+// ConnOption is an option of Listen.
+type ConnOption func(c *Conn)
+
+// WithLocalIP binds the sockets to ip.
+// By default they are bound to all the addresses, which is required to receive
+// the broadcast frames.
+func WithLocalIP(ip net.IP) ConnOption {
+	return func(c *Conn) {
+		c.localIP = ip
+	}
+}
+
+// WithLocalPorts sets the ports to listen on, instead of DefaultPorts.
+func WithLocalPorts(p Ports) ConnOption {
+	return func(c *Conn) {
+		c.localPorts = p
+	}
+}
+
+// WithRemotePorts sets the ports to send the frames to, instead of DefaultPorts.
+func WithRemotePorts(p Ports) ConnOption {
+	return func(c *Conn) {
+		c.remotePorts = p
+	}
+}
+
+// WithNodeIP sets the function which returns the IP address of a node,
+// which is called with NodeBroadcast for the broadcast frames.
+// By default NodeIP is used.
+func WithNodeIP(f func(n uint8) net.IP) ConnOption {
+	return func(c *Conn) {
+		c.nodeIP = f
+	}
+}
+
+// Conn is a UDP transport of FL-net, which listens on the ports of the token
+// and cyclic frames, the messages, the participation requests and the triggers.
+// A Conn is safe for concurrent use.
+type Conn struct {
+	localIP     net.IP
+	localPorts  Ports
+	remotePorts Ports
+	nodeIP      func(n uint8) net.IP
+
+	conns   []*net.UDPConn
+	packets chan packet
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+// packet is a frame or an error from the sockets.
+type packet struct {
+	p   *Packet
+	err error
+}
+
+// Listen creates a new Conn listening on the FL-net ports.
+func Listen(opts ...ConnOption) (*Conn, error) {
+	c := &Conn{
+		localPorts:  DefaultPorts,
+		remotePorts: DefaultPorts,
+		nodeIP:      NodeIP,
+		packets:     make(chan packet),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for _, port := range c.localPorts.list() {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: c.localIP, Port: port})
+		if err != nil {
+			for _, conn := range c.conns {
+				conn.Close()
+			}
+			return nil, err
+		}
+		c.conns = append(c.conns, conn)
+	}
+
+	for _, conn := range c.conns {
+		c.wg.Add(1)
+		go c.receive(conn)
+	}
+	return c, nil
+}
+
+// receive parses the frames from conn until c is closed.
+// An error in reading conn is passed to ReadFrame, and receiving goes on
+// unless conn is closed.
+func (c *Conn) receive(conn *net.UDPConn) {
+	defer c.wg.Done()
+
+	b := make([]byte, 65535)
+	for {
+		n, addr, err := conn.ReadFromUDP(b)
+		if err != nil {
+			select {
+			case <-c.done:
+				return
+			default:
+			}
+			c.deliver(packet{err: err})
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		f, err := Parse(b[:n])
+		if err != nil {
+			err = errors.Wrapf(err, "from %v", addr)
+		}
+		c.deliver(packet{p: &Packet{Frame: f, Addr: addr}, err: err})
+	}
+}
+
+// deliver passes p to ReadFrame, or drops it if c is closed.
+func (c *Conn) deliver(p packet) {
+	select {
+	case c.packets <- p:
+	case <-c.done:
+	}
+}
+
+// ReadFrame returns the next frame received on any of the ports.
+// If the frame cannot be parsed, the error is returned with the Packet
+// of the sender and nil Frame, and the next frame can be read.
+// An error in reading a socket, such as a connection reset caused by
+// a frame sent to an absent node, is returned with nil Packet, and
+// the next frame can be read as well.
+// It returns ErrConnClosed after c is closed.
+func (c *Conn) ReadFrame(ctx context.Context) (*Packet, error) {
+	select {
+	case p := <-c.packets:
+		return p.p, p.err
+	case <-c.done:
+		return nil, ErrConnClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Send sends the frame to the node in DA, or broadcasts it if DA is
+// NodeBroadcast. The port is chosen by the TCD.
+func (c *Conn) Send(f FLnet) error {
+	b, err := f.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return err
+	}
+
+	addr := &net.UDPAddr{
+		IP:   c.nodeIP(h.DestNode()),
+		Port: c.remotePorts.list()[socket(h.TCD)],
+	}
+	return c.write(b, h.TCD, addr)
+}
+
+// SendTo sends the frame to addr, regardless of DA.
+func (c *Conn) SendTo(f FLnet, addr *net.UDPAddr) error {
+	b, err := f.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return err
+	}
+	return c.write(b, h.TCD, addr)
+}
+
+// write sends b from the socket of the port for the TCD.
+func (c *Conn) write(b []byte, tcd uint16, addr *net.UDPAddr) error {
+	select {
+	case <-c.done:
+		return ErrConnClosed
+	default:
+	}
+
+	_, err := c.conns[socket(tcd)].WriteToUDP(b, addr)
+	return err
+}
+
+// LocalPorts returns the ports which c listens on.
+// The ports given as 0 to WithLocalPorts are replaced with the ones chosen
+// by the system.
+func (c *Conn) LocalPorts() Ports {
+	port := func(i int) int {
+		return c.conns[i].LocalAddr().(*net.UDPAddr).Port
+	}
+	return Ports{
+		Cyclic:        port(0),
+		Message:       port(1),
+		Participation: port(2),
+		Trigger:       port(3),
+	}
+}
+
+// Close closes the sockets of c.
+func (c *Conn) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.done)
+		for _, conn := range c.conns {
+			if e := conn.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+		c.wg.Wait()
+	})
+	return err
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+var loopback = net.IPv4(127, 0, 0, 1)
+
+// listenLoopback returns a Conn on 127.0.0.1 with the ports chosen by the system,
+// which sends the frames to the ports in remote.
+func listenLoopback(t *testing.T, remote flnet.Ports) *flnet.Conn {
+	t.Helper()
+
+	c, err := flnet.Listen(
+		flnet.WithLocalIP(loopback),
+		flnet.WithLocalPorts(flnet.Ports{}),
+		flnet.WithRemotePorts(remote),
+		flnet.WithNodeIP(func(uint8) net.IP { return loopback }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestConn(t *testing.T) {
+	rx := listenLoopback(t, flnet.Ports{})
+	defer rx.Close()
+	tx := listenLoopback(t, rx.LocalPorts())
+	defer tx.Close()
+
+	data := []byte{0x12, 0x34}
+	frames := []struct {
+		frame flnet.FLnet
+		port  int
+	}{
+		{flnet.NewCyclic(1, 255, 0, 0, 1, 0, 0, &data), tx.LocalPorts().Cyclic},
+		{flnet.NewByteBlockReadRequest(1, 2, 0, 1, 0x1000, 4), tx.LocalPorts().Message},
+		{flnet.NewParticipationRequest(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."), tx.LocalPorts().Participation},
+		{flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."), tx.LocalPorts().Trigger},
+	}
+
+	for _, f := range frames {
+		if err := tx.Send(f.frame); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		p, err := rx.ReadFrame(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("%T: %v", f.frame, err)
+		}
+
+		if diff := cmp.Diff(f.frame, p.Frame); diff != "" {
+			t.Errorf("%T: %s", f.frame, diff)
+		}
+		if !p.Addr.IP.Equal(loopback) || p.Addr.Port != f.port {
+			t.Errorf("%T: got from %v, want from port %d", f.frame, p.Addr, f.port)
+		}
+	}
+}
+
+func TestConnParseError(t *testing.T) {
+	rx := listenLoopback(t, flnet.Ports{})
+	defer rx.Close()
+
+	u, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: loopback, Port: rx.LocalPorts().Message})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer u.Close()
+	if _, err := u.Write([]byte{0x46, 0x41, 0x43, 0x4e}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, err := rx.ReadFrame(ctx)
+	if !errors.Is(err, flnet.ErrTooShortToParse) {
+		t.Errorf("got %v, want %v", err, flnet.ErrTooShortToParse)
+	}
+	if p == nil || p.Frame != nil || p.Addr.Port != u.LocalAddr().(*net.UDPAddr).Port {
+		t.Errorf("got %+v, want the packet from %v without frame", p, u.LocalAddr())
+	}
+}
+
+func TestConnClose(t *testing.T) {
+	c := listenLoopback(t, flnet.Ports{})
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	if _, err := c.ReadFrame(context.Background()); !errors.Is(err, flnet.ErrConnClosed) {
+		t.Errorf("ReadFrame: got %v, want %v", err, flnet.ErrConnClosed)
+	}
+	if err := c.Send(flnet.NewToken()); !errors.Is(err, flnet.ErrConnClosed) {
+		t.Errorf("Send: got %v, want %v", err, flnet.ErrConnClosed)
+	}
+}
+
+func TestConnReadError(t *testing.T) {
+	rx := listenLoopback(t, flnet.Ports{})
+	defer rx.Close()
+	tx := listenLoopback(t, rx.LocalPorts())
+	defer tx.Close()
+
+	if err := flnet.SetMessageReadDeadline(rx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, err := rx.ReadFrame(ctx)
+	var ne net.Error
+	if !errors.As(err, &ne) || !ne.Timeout() || p != nil {
+		t.Fatalf("got %v, %v, want a timeout error without packet", p, err)
+	}
+
+	if err := flnet.SetMessageReadDeadline(rx, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	req := flnet.NewByteBlockReadRequest(1, 2, 0, 1, 0x1000, 4)
+	if err := tx.Send(req); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		p, err = rx.ReadFrame(ctx)
+		if errors.As(err, &ne) && ne.Timeout() {
+			continue
+		}
+		break
+	}
+	if err != nil {
+		t.Fatalf("the socket stopped receiving after the error: %v", err)
+	}
+	if diff := cmp.Diff(req, p.Frame); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"github.com/pkg/errors"
+)
+
+// CyclicBlockSize is the maximum size of the cyclic data in a frame.
+const CyclicBlockSize = 1024
+
+// CyclicImage is the cyclic data of a node, which is the image of its
+// Area1 and Area2 of the common memory.
+// The addresses are in words and the data are 2 bytes per word.
+type CyclicImage struct {
+	Area1Address uint16
+	Area1        []byte
+	Area2Address uint16
+	Area2        []byte
+}
+
+// SplitCyclic splits the cyclic data of a node into Cyclic frames.
+// Each frame carries up to CyclicBlockSize bytes, numbered with CBN and TBN,
+// and C_AD1/C_SZ1 and C_AD2/C_SZ2 describe the part of the areas in the frame.
+func SplitCyclic(sna, dna uint8, vseq uint32, img *CyclicImage) ([]*Cyclic, error) {
+	if len(img.Area1)%2 != 0 {
+		return nil, errors.Wrapf(ErrInvalidFrame, "Area1 of %d bytes is not word aligned", len(img.Area1))
+	}
+	if len(img.Area2)%2 != 0 {
+		return nil, errors.Wrapf(ErrInvalidFrame, "Area2 of %d bytes is not word aligned", len(img.Area2))
+	}
+
+	data := make([]byte, 0, len(img.Area1)+len(img.Area2))
+	data = append(data, img.Area1...)
+	data = append(data, img.Area2...)
+
+	tbn := (len(data) + CyclicBlockSize - 1) / CyclicBlockSize
+	if tbn == 0 {
+		tbn = 1
+	}
+	if tbn > 0xff {
+		return nil, errors.Wrapf(ErrInvalidFrame, "cyclic data of %d bytes exceeds %d blocks", len(data), 0xff)
+	}
+
+	frames := make([]*Cyclic, 0, tbn)
+	for i := 0; i < tbn; i++ {
+		start := i * CyclicBlockSize
+		end := start + CyclicBlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		var cad1, csz1, cad2, csz2 uint16
+		if start < len(img.Area1) {
+			e := minInt(end, len(img.Area1))
+			cad1 = img.Area1Address + uint16(start/2)
+			csz1 = uint16((e - start) / 2)
+		}
+		if end > len(img.Area1) {
+			s := maxInt(start, len(img.Area1)) - len(img.Area1)
+			cad2 = img.Area2Address + uint16(s/2)
+			csz2 = uint16((end-len(img.Area1))/2 - s/2)
+		}
+
+		block := data[start:end]
+		c := NewCyclic(sna, dna, vseq, cad1, csz1, cad2, csz2, &block)
+		c.Header.CBN = uint8(i + 1)
+		c.Header.TBN = uint8(tbn)
+		c.Header.TFL = uint32(c.Header.MarshalLen() + len(data))
+		frames = append(frames, c)
+	}
+
+	return frames, nil
+}
+
+// CyclicReassembler rebuilds the cyclic data of a node from the Cyclic frames
+// split by CBN and TBN, and the Token frames carrying the cyclic data.
+// The areas of the blocks are copied when they are added, so the frames can be
+// reused after Add returns, such as the ones returned by Decoder.
+type CyclicReassembler struct {
+	sa     uint32
+	tbn    uint8
+	last   uint8
+	blocks map[uint8]cyclicBlock
+}
+
+// cyclicBlock is the copy of the areas in a block.
+type cyclicBlock struct {
+	area1, area2 *Area
+}
+
+// newCyclicBlock returns the copy of the areas in the cyclic data of a frame.
+// An area whose size is 0 is nil.
+func newCyclicBlock(h *FALinkHeader, data []byte) cyclicBlock {
+	var b cyclicBlock
+	a1, a2 := areas(h, data)
+	if h.CSZ1 > 0 {
+		b.area1 = &Area{Address: a1.Address, Data: append([]byte(nil), a1.Data...)}
+	}
+	if h.CSZ2 > 0 {
+		b.area2 = &Area{Address: a2.Address, Data: append([]byte(nil), a2.Data...)}
+	}
+	return b
+}
+
+// NewCyclicReassembler creates a new CyclicReassembler.
+func NewCyclicReassembler() *CyclicReassembler {
+	return &CyclicReassembler{
+		blocks: map[uint8]cyclicBlock{},
+	}
+}
+
+// Reset discards the received blocks.
+func (r *CyclicReassembler) Reset() {
+	r.sa = 0
+	r.tbn = 0
+	r.last = 0
+	r.blocks = map[uint8]cyclicBlock{}
+}
+
+// Add adds a received block, and reports whether all the blocks are received.
+// A block received out of order is added with ErrCyclicOutOfOrder.
+// A block of another node than the preceding ones is not added and
+// ErrCyclicMismatch is returned.
+// A block of CBN 1, a block after the completion, and a block whose number is
+// not after the last one or whose TBN differs start a new transmission,
+// discarding the preceding blocks, so that a lost block does not mix two
+// transmissions.
+func (r *CyclicReassembler) Add(c *Cyclic) (bool, error) {
+	if c.Header.TCD != TCDCyclic {
+		return false, errors.Wrapf(ErrCyclicMismatch, "TCD %d", c.Header.TCD)
+	}
+	return r.add(c.Header, c.Data)
+}
+
+// AddToken adds the block carried by a received token like Add.
+// The token holder may send the last block with the token after the Cyclic
+// frames, or all of its cyclic data in the token as a single block.
+func (r *CyclicReassembler) AddToken(t *Token) (bool, error) {
+	if t.Header.TCD != TCDToken {
+		return false, errors.Wrapf(ErrCyclicMismatch, "TCD %d", t.Header.TCD)
+	}
+	return r.add(t.Header, t.Data)
+}
+
+// add adds the block of the cyclic data in a frame with the header h.
+func (r *CyclicReassembler) add(h *FALinkHeader, data []byte) (bool, error) {
+	if h.CBN < 1 || h.CBN > h.TBN {
+		return false, errors.Wrapf(ErrInvalidFrame, "CBN %d of TBN %d", h.CBN, h.TBN)
+	}
+
+	if r.tbn != 0 && h.SA != r.sa {
+		return false, errors.Wrapf(ErrCyclicMismatch, "SA 0x%08x, want SA 0x%08x", h.SA, r.sa)
+	}
+	if h.CBN == 1 || h.CBN <= r.last || h.TBN != r.tbn || r.Complete() {
+		r.Reset()
+		r.sa = h.SA
+		r.tbn = h.TBN
+	}
+
+	var err error
+	if h.CBN != r.last+1 {
+		err = errors.Wrapf(ErrCyclicOutOfOrder, "CBN %d after %d", h.CBN, r.last)
+	}
+	r.blocks[h.CBN] = newCyclicBlock(h, data)
+	r.last = h.CBN
+
+	return r.Complete(), err
+}
+
+// Complete reports whether all the blocks are received.
+func (r *CyclicReassembler) Complete() bool {
+	return r.tbn != 0 && len(r.blocks) == int(r.tbn)
+}
+
+// Missing returns the block numbers which are not received yet.
+func (r *CyclicReassembler) Missing() []uint8 {
+	var missing []uint8
+	for i := 1; i <= int(r.tbn); i++ {
+		if _, ok := r.blocks[uint8(i)]; !ok {
+			missing = append(missing, uint8(i))
+		}
+	}
+	return missing
+}
+
+// Image returns the cyclic data rebuilt from the received blocks.
+// It returns ErrCyclicIncomplete if any block is missing.
+func (r *CyclicReassembler) Image() (*CyclicImage, error) {
+	if !r.Complete() {
+		return nil, errors.Wrapf(ErrCyclicIncomplete, "missing CBN %v", r.Missing())
+	}
+
+	img := &CyclicImage{}
+	a1 := newAreaBuilder()
+	a2 := newAreaBuilder()
+	for i := 1; i <= int(r.tbn); i++ {
+		b := r.blocks[uint8(i)]
+		if b.area1 != nil {
+			a1.add(*b.area1)
+		}
+		if b.area2 != nil {
+			a2.add(*b.area2)
+		}
+	}
+	img.Area1Address, img.Area1 = a1.build()
+	img.Area2Address, img.Area2 = a2.build()
+
+	return img, nil
+}
+
+// areaBuilder builds an area of the common memory from the parts in blocks.
+type areaBuilder struct {
+	start, end int
+	parts      map[int][]byte
+}
+
+func newAreaBuilder() *areaBuilder {
+	return &areaBuilder{
+		start: -1,
+		parts: map[int][]byte{},
+	}
+}
+
+// add adds the part of the area.
+func (a *areaBuilder) add(part Area) {
+	addr := int(part.Address)
+	if a.start < 0 || addr < a.start {
+		a.start = addr
+	}
+	if e := addr + part.Words(); e > a.end {
+		a.end = e
+	}
+	a.parts[addr] = part.Data
+}
+
+// build returns the top address and the data of the area.
+// The words not covered by any part are left zero.
+func (a *areaBuilder) build() (uint16, []byte) {
+	if a.start < 0 {
+		return 0, nil
+	}
+
+	b := make([]byte, (a.end-a.start)*2)
+	for addr, data := range a.parts {
+		copy(b[(addr-a.start)*2:], data)
+	}
+	return uint16(a.start), b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
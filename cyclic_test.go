@@ -0,0 +1,336 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func newTestImage(area1Len, area2Len int) *flnet.CyclicImage {
+	img := &flnet.CyclicImage{
+		Area1Address: 10,
+		Area1:        make([]byte, area1Len),
+		Area2Address: 100,
+		Area2:        make([]byte, area2Len),
+	}
+	for i := range img.Area1 {
+		img.Area1[i] = byte(i)
+	}
+	for i := range img.Area2 {
+		img.Area2[i] = byte(i * 7)
+	}
+	return img
+}
+
+func TestSplitCyclic(t *testing.T) {
+	type block struct {
+		CBN, TBN               uint8
+		CAD1, CSZ1, CAD2, CSZ2 uint16
+		TFL                    uint32
+		BSize                  uint16
+		DataLen                int
+	}
+
+	cases := []struct {
+		description string
+		img         *flnet.CyclicImage
+		blocks      []block
+	}{
+		{
+			"single block",
+			newTestImage(8, 128),
+			[]block{
+				{1, 1, 10, 4, 100, 64, 200, 200, 136},
+			},
+		},
+		{
+			"no data",
+			newTestImage(0, 0),
+			[]block{
+				{1, 1, 0, 0, 0, 0, 64, 64, 0},
+			},
+		},
+		{
+			"three blocks",
+			newTestImage(600, 1500),
+			[]block{
+				{1, 3, 10, 300, 100, 212, 2164, 1088, 1024},
+				{2, 3, 0, 0, 312, 512, 2164, 1088, 1024},
+				{3, 3, 0, 0, 824, 26, 2164, 116, 52},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			frames, err := flnet.SplitCyclic(1, 255, 3, c.img)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []block
+			for _, f := range frames {
+				h := f.Header
+				got = append(got, block{
+					h.CBN, h.TBN, h.CAD1, h.CSZ1, h.CAD2, h.CSZ2, h.TFL, h.BSize, len(f.Data),
+				})
+				if err := f.Validate(); err != nil {
+					t.Errorf("CBN %d: %v", h.CBN, err)
+				}
+			}
+			if diff := cmp.Diff(c.blocks, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("odd length", func(t *testing.T) {
+		if _, err := flnet.SplitCyclic(1, 255, 0, newTestImage(3, 0)); !errors.Is(err, flnet.ErrInvalidFrame) {
+			t.Errorf("got %v, want %v", err, flnet.ErrInvalidFrame)
+		}
+	})
+}
+
+func TestCyclicReassembler(t *testing.T) {
+	img := newTestImage(600, 1500)
+	frames, err := flnet.SplitCyclic(1, 255, 3, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("in order through the wire", func(t *testing.T) {
+		r := flnet.NewCyclicReassembler()
+		for i, f := range frames {
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := flnet.Parse(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			complete, err := r.Add(p.(*flnet.Cyclic))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := complete, i == len(frames)-1; got != want {
+				t.Errorf("CBN %d: got complete=%v, want %v", i+1, got, want)
+			}
+		}
+
+		got, err := r.Image()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(img, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	for _, mode := range []flnet.PayloadMode{flnet.CopyPayload, flnet.AliasPayload} {
+		t.Run(fmt.Sprintf("through a Decoder of mode %d", mode), func(t *testing.T) {
+			d := flnet.NewDecoder(mode)
+			buf := make([]byte, 2048)
+			r := flnet.NewCyclicReassembler()
+			for _, f := range frames {
+				n := f.MarshalLen()
+				if err := f.MarshalTo(buf[:n]); err != nil {
+					t.Fatal(err)
+				}
+				p, err := d.Decode(buf[:n])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := r.Add(p.(*flnet.Cyclic)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := r.Image()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(img, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("last block in a token", func(t *testing.T) {
+		r := flnet.NewCyclicReassembler()
+		for _, f := range frames[:len(frames)-1] {
+			if _, err := r.Add(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+		h := frames[len(frames)-1].Header
+		tok := flnet.NewTokenWithCyclic(1, 2, 3, h.CAD1, h.CSZ1, h.CAD2, h.CSZ2, frames[len(frames)-1].Data)
+		tok.Header.CBN, tok.Header.TBN = h.CBN, h.TBN
+		complete, err := r.AddToken(tok)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !complete {
+			t.Fatalf("not complete, missing %v", r.Missing())
+		}
+
+		got, err := r.Image()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(img, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("token only", func(t *testing.T) {
+		r := flnet.NewCyclicReassembler()
+		tok := flnet.NewTokenWithCyclic(1, 2, 3, 10, 1, 100, 1, []byte{0x12, 0x34, 0x56, 0x78})
+		complete, err := r.AddToken(tok)
+		if err != nil || !complete {
+			t.Fatalf("got complete=%v, %v, want complete", complete, err)
+		}
+
+		got, err := r.Image()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &flnet.CyclicImage{
+			Area1Address: 10,
+			Area1:        []byte{0x12, 0x34},
+			Area2Address: 100,
+			Area2:        []byte{0x56, 0x78},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("out of order and missing", func(t *testing.T) {
+		r := flnet.NewCyclicReassembler()
+		if _, err := r.Add(frames[1]); !errors.Is(err, flnet.ErrCyclicOutOfOrder) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicOutOfOrder)
+		}
+		if diff := cmp.Diff([]uint8{1, 3}, r.Missing()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+		if _, err := r.Image(); !errors.Is(err, flnet.ErrCyclicIncomplete) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicIncomplete)
+		}
+
+		complete, err := r.Add(frames[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if complete {
+			t.Error("complete without CBN 1")
+		}
+		if diff := cmp.Diff([]uint8{1}, r.Missing()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("lost first block", func(t *testing.T) {
+		next := newTestImage(600, 1500)
+		for i := range next.Area2 {
+			next.Area2[i] = 0xff
+		}
+		nextFrames, err := flnet.SplitCyclic(1, 255, 3, next)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := flnet.NewCyclicReassembler()
+		if _, err := r.Add(frames[1]); !errors.Is(err, flnet.ErrCyclicOutOfOrder) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicOutOfOrder)
+		}
+		for _, f := range nextFrames {
+			if _, err := r.Add(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := r.Image()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(next, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("TBN changed", func(t *testing.T) {
+		small := newTestImage(4, 4)
+		smallFrames, err := flnet.SplitCyclic(1, 255, 3, small)
+		if err != nil {
+			t.Fatal(err)
+		}
+		two, err := flnet.SplitCyclic(1, 255, 3, newTestImage(600, 600))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := flnet.NewCyclicReassembler()
+		if _, err := r.Add(frames[1]); !errors.Is(err, flnet.ErrCyclicOutOfOrder) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicOutOfOrder)
+		}
+		if _, err := r.Add(two[1]); !errors.Is(err, flnet.ErrCyclicOutOfOrder) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicOutOfOrder)
+		}
+		if diff := cmp.Diff([]uint8{1}, r.Missing()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+
+		complete, err := r.Add(smallFrames[0])
+		if err != nil || !complete {
+			t.Fatalf("got complete=%v, %v, want complete", complete, err)
+		}
+		got, err := r.Image()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(small, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("another node", func(t *testing.T) {
+		other, err := flnet.SplitCyclic(2, 255, 3, img)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := flnet.NewCyclicReassembler()
+		if _, err := r.Add(frames[0]); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Add(other[1]); !errors.Is(err, flnet.ErrCyclicMismatch) {
+			t.Errorf("got %v, want %v", err, flnet.ErrCyclicMismatch)
+		}
+		if diff := cmp.Diff([]uint8{2, 3}, r.Missing()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("new transmission", func(t *testing.T) {
+		r := flnet.NewCyclicReassembler()
+		for _, f := range frames {
+			if _, err := r.Add(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := r.Add(frames[0]); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]uint8{2, 3}, r.Missing()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+}
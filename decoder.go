@@ -0,0 +1,85 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+// PayloadMode specifies how a Decoder stores the payload of the frames.
+type PayloadMode int
+
+// PayloadMode definitions.
+const (
+	// CopyPayload copies the payload into the buffer owned by the frame,
+	// so the given bytes can be reused after Decode returns.
+	CopyPayload PayloadMode = iota
+	// AliasPayload makes the payload refer to the given bytes,
+	// which must not be modified while the frame is in use.
+	AliasPayload
+)
+
+// frameKey identifies the frame type chosen for a frame.
+type frameKey struct {
+	tcd uint16
+	rpl bool
+}
+
+// Decoder decodes frames like Parse, reusing one frame per TCD and
+// request/response. Once a kind of frame has been seen, decoding the token,
+// cyclic and message frames of the kind allocates no memory.
+// The frames carrying a network parameter or a device profile reuse their
+// structs, but allocate the decoded strings.
+//
+// The frames of the TCDs registered by RegisterDecoder are not reused, but
+// created by the registered decoder for every frame.
+//
+// The frame returned by Decode is overwritten by the next frame of the same
+// kind, so it must not be retained or used after that. Helpers which keep
+// the frames given to them must not be given the frames from a Decoder,
+// unless they copy the frames as CyclicReassembler does.
+// A Decoder is not safe for concurrent use.
+type Decoder struct {
+	mode   PayloadMode
+	frames map[frameKey]FLnet
+}
+
+// NewDecoder creates a new Decoder which stores the payload as mode.
+func NewDecoder(mode PayloadMode) *Decoder {
+	return &Decoder{
+		mode:   mode,
+		frames: map[frameKey]FLnet{},
+	}
+}
+
+// Decode decodes the given bytes into the frame kept for its kind.
+// If a decoder is registered by RegisterDecoder for the TCD, the bytes are
+// decoded into a new frame from it with UnmarshalBinary, which copies the payload.
+func (d *Decoder) Decode(b []byte) (FLnet, error) {
+	t, rpl, err := peekFrame(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if f := registeredFrame(t); f != nil {
+		if err := unmarshalFrame(f, t, b, false); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	k := frameKey{tcd: t, rpl: rpl}
+	f, ok := d.frames[k]
+	if !ok {
+		f = newFrame(t, rpl)
+		d.frames[k] = f
+	}
+
+	if err := unmarshalFrame(f, t, b, d.mode == AliasPayload); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reset drops the frames kept by d, so that they are no longer overwritten.
+func (d *Decoder) Reset() {
+	d.frames = map[frameKey]FLnet{}
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestParseCopiesPayload(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04}
+	b, err := flnet.NewCyclic(1, 255, 0, 0, 1, 0, 1, &data).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := flnet.Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[64] = 0xff
+
+	if got := f.(*flnet.Cyclic).Data; !bytes.Equal(got, data) {
+		t.Errorf("Data changed with the input: got %x, want %x", got, data)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	data := []byte{0x01, 0x02, 0x03, 0x04}
+	b, err := flnet.NewCyclic(1, 255, 0, 0, 1, 0, 1, &data).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Copy", func(t *testing.T) {
+		d := flnet.NewDecoder(flnet.CopyPayload)
+		buf := append([]byte(nil), b...)
+		f, err := d.Decode(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf[64] = 0xff
+
+		if got := f.(*flnet.Cyclic).Data; !bytes.Equal(got, data) {
+			t.Errorf("got %x, want %x", got, data)
+		}
+	})
+
+	t.Run("Alias", func(t *testing.T) {
+		d := flnet.NewDecoder(flnet.AliasPayload)
+		buf := append([]byte(nil), b...)
+		f, err := d.Decode(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf[64] = 0xff
+
+		if got := f.(*flnet.Cyclic).Data[0]; got != 0xff {
+			t.Errorf("Data does not refer to the input: got %#02x", got)
+		}
+	})
+
+	t.Run("Reuse", func(t *testing.T) {
+		d := flnet.NewDecoder(flnet.CopyPayload)
+		f1, err := d.Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f2, err := d.Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f1 != f2 {
+			t.Error("frame is not reused for the same TCD")
+		}
+
+		req, err := flnet.NewByteBlockReadRequest(1, 2, 0, 1, 0, 4).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0, data).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, err := d.Decode(req); err != nil {
+			t.Error(err)
+		} else if _, ok := f.(*flnet.ByteBlockReadRequest); !ok {
+			t.Errorf("got %T, want *flnet.ByteBlockReadRequest", f)
+		}
+		if f, err := d.Decode(res); err != nil {
+			t.Error(err)
+		} else if _, ok := f.(*flnet.ByteBlockReadResponse); !ok {
+			t.Errorf("got %T, want *flnet.ByteBlockReadResponse", f)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		d := flnet.NewDecoder(flnet.CopyPayload)
+		_, err := d.Decode(b[:30])
+		if !errors.Is(err, flnet.ErrTooShortToParse) {
+			t.Errorf("got %v, want %v", err, flnet.ErrTooShortToParse)
+		}
+	})
+
+	t.Run("Reuse structs", func(t *testing.T) {
+		d := flnet.NewDecoder(flnet.CopyPayload)
+		res, err := flnet.NewNetworkParameterReadResponse(2, 1, 0, 1, &flnet.NetworkParameter{NodeName: "NODE"}).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		f1, err := d.Decode(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := f1.(*flnet.NetworkParameterReadResponse).Parameter
+		f2, err := d.Decode(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f2.(*flnet.NetworkParameterReadResponse).Parameter != p {
+			t.Error("network parameter is not reused")
+		}
+	})
+
+	frames := []flnet.FLnet{
+		flnet.NewCyclic(1, 255, 0, 0, 1, 0, 1, &data),
+		flnet.NewTokenWithCyclic(1, 2, 0, 0, 1, 0, 1, data),
+		flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0, data),
+		flnet.NewWordBlockReadResponse(2, 1, 0, 1, 0, []uint16{1, 2}),
+		flnet.NewLogDataReadResponse(2, 1, 0, 1, &flnet.LogData{SendCount: 1}),
+	}
+	for _, mode := range []flnet.PayloadMode{flnet.CopyPayload, flnet.AliasPayload} {
+		for _, f := range frames {
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := flnet.NewDecoder(mode)
+			if _, err := d.Decode(b); err != nil {
+				t.Fatal(err)
+			}
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, err := d.Decode(b); err != nil {
+					t.Fatal(err)
+				}
+			})
+			if allocs != 0 {
+				t.Errorf("mode %d, %T: got %v allocations per Decode, want 0", mode, f, allocs)
+			}
+		}
+	}
+}
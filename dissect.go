@@ -0,0 +1,290 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Dissect returns the human-readable tree of the frame, which shows every
+// field with its byte offset, raw value and meaning, like the tree view of
+// a packet analyzer. An error in encoding the frame is written in the tree.
+func Dissect(f FLnet) string {
+	var sb strings.Builder
+	if err := DissectTo(&sb, f); err != nil {
+		fmt.Fprintf(&sb, "error: %v\n", err)
+	}
+	return sb.String()
+}
+
+// DissectTo writes the human-readable tree of the frame to w.
+// See Dissect for the format.
+func DissectTo(w io.Writer, f FLnet) error {
+	b, err := f.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	h, err := ParseHeader(b)
+	if err != nil {
+		return err
+	}
+
+	d := &dissector{w: w}
+	d.printf("FL-net %s, %d bytes\n", frameKind(h), len(b))
+	d.header(h, b)
+
+	payload := b[h.MarshalLen():]
+	switch fr := f.(type) {
+	case *Token:
+		d.areas(h, payload)
+	case *Cyclic:
+		d.areas(h, payload)
+	case *Trigger:
+		d.participation(fr.ParticipationHeader, b)
+	case *ParticipationRequest:
+		d.participation(fr.ParticipationHeader, b)
+	case *NetworkParameterReadResponse:
+		d.value("Network parameter", fr.Parameter, payload)
+	case *NetworkParameterWriteRequest:
+		d.value("Network parameter", fr.Parameter, payload)
+	case *ProfileReadResponse:
+		d.value("Device profile", fr.Profile, payload)
+	case *LogDataReadResponse:
+		d.value("Log data", fr.Log, payload)
+	default:
+		if len(payload) > 0 {
+			d.printf("  Data (%d bytes)\n", len(payload))
+			d.dump(payload, h.MarshalLen(), "    ")
+		}
+	}
+
+	return d.err
+}
+
+// dissector writes a tree to w, keeping the first error in writing.
+type dissector struct {
+	w   io.Writer
+	err error
+}
+
+func (d *dissector) printf(format string, a ...interface{}) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, format, a...)
+}
+
+// field writes a field at offset with its raw bytes and meaning.
+func (d *dissector) field(offset int, name string, raw []byte, meaning string) {
+	line := fmt.Sprintf("    [0x%02x] %-8s %-10s %s", offset, name, hex.EncodeToString(raw), meaning)
+	d.printf("%s\n", strings.TrimRight(line, " "))
+}
+
+// header writes the fields of the header h encoded in b.
+func (d *dissector) header(h *FALinkHeader, b []byte) {
+	d.printf("  FA Link header\n")
+	for i, f := range headerFields {
+		end := h.MarshalLen()
+		if i+1 < len(headerFields) {
+			end = headerFields[i+1].offset
+		}
+		d.field(f.offset, f.name, b[f.offset:end], headerMeaning(h, f.name))
+	}
+}
+
+// areas writes the summaries of the cyclic data in Area1 and Area2.
+func (d *dissector) areas(h *FALinkHeader, data []byte) {
+	a1, a2 := areas(h, data)
+	offset := h.MarshalLen()
+	for _, a := range []struct {
+		name string
+		area Area
+	}{
+		{"Area1", a1},
+		{"Area2", a2},
+	} {
+		if len(a.area.Data) == 0 {
+			continue
+		}
+		d.printf("  %s: words 0x%04x-0x%04x (%d words, %d bytes)\n",
+			a.name, a.area.Address, int(a.area.Address)+a.area.Words()-1, a.area.Words(), len(a.area.Data),
+		)
+		d.dump(a.area.Data, offset, "    ")
+		offset += len(a.area.Data)
+	}
+	if rest := data[offset-h.MarshalLen():]; len(rest) > 0 {
+		d.printf("  Extra data (%d bytes)\n", len(rest))
+		d.dump(rest, offset, "    ")
+	}
+}
+
+// participation writes the node information of the frame encoded in b.
+func (d *dissector) participation(p *ParticipationHeader, b []byte) {
+	d.printf("  Node information\n")
+	for _, f := range []struct {
+		offset int
+		name   string
+		value  [10]byte
+	}{
+		{64, "NDN", p.NDN},
+		{74, "VDN", p.VDN},
+		{84, "MSN", p.MSN},
+	} {
+		d.field(f.offset, f.name, b[f.offset:f.offset+len(f.value)], fmt.Sprintf("%q", nodeInfo(f.value)))
+	}
+	d.field(94, "Reserve", b[94:96], "")
+}
+
+// value writes the decoded payload v with the dump of its bytes.
+func (d *dissector) value(name string, v interface{}, payload []byte) {
+	d.printf("  %s (%d bytes)\n", name, len(payload))
+	d.printf("    %+v\n", v)
+	d.dump(payload, 64, "    ")
+}
+
+// dump writes b in rows of 16 bytes, labeled with the offset in the frame.
+func (d *dissector) dump(b []byte, offset int, indent string) {
+	for i := 0; i < len(b); i += 16 {
+		end := i + 16
+		if end > len(b) {
+			end = len(b)
+		}
+		d.printf("%s[0x%04x] % x\n", indent, offset+i, b[i:end])
+	}
+}
+
+// frameKind returns the name of the frame in h, with "request" or "response"
+// for the messages.
+func frameKind(h *FALinkHeader) string {
+	name := tcdName(h.TCD)
+	switch h.TCD {
+	case TCDToken, TCDCyclic, TCDParticipationRequest, TCDTrigger:
+		return name
+	}
+	if h.RPL() {
+		return name + " response"
+	}
+	return name + " request"
+}
+
+// headerMeaning returns the meaning of the field of h named name.
+func headerMeaning(h *FALinkHeader, name string) string {
+	switch name {
+	case "H_TYPE":
+		return fmt.Sprintf("%q", string(h.HType[:]))
+	case "TFL":
+		return fmt.Sprintf("%d bytes", h.TFL)
+	case "SA":
+		return nodeMeaning(h.SA)
+	case "DA":
+		return nodeMeaning(h.DA)
+	case "V_SEQ":
+		return fmt.Sprint(h.VSeq)
+	case "SEQ":
+		return fmt.Sprint(h.Seq)
+	case "M_CTL":
+		return mctlString(h.MCTL)
+	case "ULS":
+		return ulsMeaning(UpperLayerStatus(h.ULS))
+	case "M_SZ":
+		return fmt.Sprint(h.MSZ)
+	case "M_ADD":
+		return fmt.Sprintf("0x%08x", h.MADD)
+	case "MFT":
+		return fmt.Sprint(h.MFT)
+	case "M_RLT":
+		if !h.RPL() {
+			return ""
+		}
+		if err := resultError(h.MRLT); err != nil {
+			return err.Error()
+		}
+		return "normal"
+	case "TCD":
+		return tcdName(h.TCD)
+	case "VER":
+		return fmt.Sprint(h.Ver)
+	case "C_AD1":
+		return fmt.Sprintf("word 0x%04x", h.CAD1)
+	case "C_SZ1":
+		return fmt.Sprintf("%d words", h.CSZ1)
+	case "C_AD2":
+		return fmt.Sprintf("word 0x%04x", h.CAD2)
+	case "C_SZ2":
+		return fmt.Sprintf("%d words", h.CSZ2)
+	case "MODE":
+		mode := "normal mode"
+		if h.TokenMode() {
+			mode = "token mode"
+		}
+		return fmt.Sprintf("version %d.%d, %s", h.MajorVersion(), h.MinorVersion(), mode)
+	case "P_TYPE":
+		return fmt.Sprintf("0x%02x", h.PType)
+	case "PRI":
+		return fmt.Sprint(h.Pri)
+	case "CBN":
+		return fmt.Sprintf("block %d", h.CBN)
+	case "TBN":
+		return fmt.Sprintf("%d blocks", h.TBN)
+	case "BSIZE":
+		return fmt.Sprintf("%d bytes", h.BSize)
+	case "LKS":
+		return lksMeaning(LinkStatus(h.LKS))
+	case "TW":
+		return fmt.Sprintf("%d ms", h.TW)
+	case "RCT":
+		return fmt.Sprint(h.RCT)
+	}
+	return ""
+}
+
+// nodeMeaning returns the node of SA or DA.
+func nodeMeaning(a uint32) string {
+	if n := uint8(a); nodeAddress(n) == a {
+		if n == NodeBroadcast {
+			return "broadcast"
+		}
+		return fmt.Sprintf("node %d", n)
+	}
+	return "invalid node address"
+}
+
+// ulsMeaning returns the decoded bits of ULS.
+func ulsMeaning(u UpperLayerStatus) string {
+	s := "STOP"
+	if u.Running() {
+		s = "RUN"
+	}
+	if u.HasError() {
+		s += ", error"
+	}
+	return fmt.Sprintf("%s, U_ERR_CODE %d", s, u.ErrCode())
+}
+
+// lksMeaning returns the decoded bits of LKS.
+func lksMeaning(l LinkStatus) string {
+	var bits []string
+	for _, b := range []struct {
+		set  bool
+		name string
+	}{
+		{l.Joined(), "joined"},
+		{l.UpperLayerError(), "upper layer error"},
+		{l.CommonMemoryValid(), "common memory valid"},
+		{l.CommonMemorySet(), "common memory set"},
+		{l.AddressOverlap(), "address overlap"},
+	} {
+		if b.set {
+			bits = append(bits, b.name)
+		}
+	}
+	if len(bits) == 0 {
+		return "none"
+	}
+	return strings.Join(bits, ", ")
+}
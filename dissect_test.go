@@ -0,0 +1,90 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestDissect(t *testing.T) {
+	m := flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0x1000, []byte{0x01, 0xab})
+	m.Header.MRLT = flnet.ResultNotExecutable
+	m.Header.ULS = uint16(flnet.NewUpperLayerStatus(true, true, 5))
+	m.Header.LKS = uint8(flnet.LKSJoined | flnet.LKSCommonMemoryValid)
+
+	want := `FL-net ByteBlockRead response, 66 bytes
+  FA Link header
+    [0x00] H_TYPE   4641434e   "FACN"
+    [0x04] TFL      00000042   66 bytes
+    [0x08] SA       00010002   node 2
+    [0x0c] DA       00010001   node 1
+    [0x10] V_SEQ    00000000   0
+    [0x14] SEQ      00000001   1
+    [0x18] M_CTL    60000000   PPT|RPL
+    [0x1c] ULS      c005       RUN, error, U_ERR_CODE 5
+    [0x1e] M_SZ     0002       2
+    [0x20] M_ADD    00001000   0x00001000
+    [0x24] MFT      00         0
+    [0x25] M_RLT    02         request not executable by the node
+    [0x26] reserved 0000
+    [0x28] TCD      fdeb       ByteBlockRead
+    [0x2a] VER      0000       0
+    [0x2c] C_AD1    0000       word 0x0000
+    [0x2e] C_SZ1    0000       0 words
+    [0x30] C_AD2    0000       word 0x0000
+    [0x32] C_SZ2    0000       0 words
+    [0x34] MODE     0031       version 3.0, token mode
+    [0x36] P_TYPE   80         0x80
+    [0x37] PRI      00         0
+    [0x38] CBN      01         block 1
+    [0x39] TBN      01         1 blocks
+    [0x3a] BSIZE    0042       66 bytes
+    [0x3c] LKS      a0         joined, common memory valid
+    [0x3d] TW       32         50 ms
+    [0x3e] RCT      0000       0
+  Data (2 bytes)
+    [0x0040] 01 ab
+`
+	if diff := cmp.Diff(want, flnet.Dissect(m)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestDissectCyclic(t *testing.T) {
+	data := []byte{0x80, 0x01, 0x12, 0x34, 0x56, 0x78, 0xff}
+	c := flnet.NewCyclic(3, 255, 7, 0x10, 1, 0x200, 2, &data)
+
+	got := flnet.Dissect(c)
+	for _, s := range []string{
+		"FL-net Cyclic, 71 bytes\n",
+		"    [0x0c] DA       000100ff   broadcast\n",
+		"  Area1: words 0x0010-0x0010 (1 words, 2 bytes)\n    [0x0040] 80 01\n",
+		"  Area2: words 0x0200-0x0201 (2 words, 4 bytes)\n    [0x0042] 12 34 56 78\n",
+		"  Extra data (1 bytes)\n    [0x0046] ff\n",
+	} {
+		if !strings.Contains(got, s) {
+			t.Errorf("%q is not in\n%s", s, got)
+		}
+	}
+}
+
+func TestDissectTo(t *testing.T) {
+	tr := flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF.")
+
+	var sb strings.Builder
+	if err := flnet.DissectTo(&sb, tr); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sb.String(), flnet.Dissect(tr); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if s := `    [0x4a] VDN      56454e444f5220202020 "VENDOR"`; !strings.Contains(sb.String(), s) {
+		t.Errorf("%q is not in\n%s", s, sb.String())
+	}
+}
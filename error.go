@@ -4,11 +4,45 @@
 
 package flnet
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error definitions.
 var (
 	ErrTooShortToMarshalBinary = errors.New("insufficient buffer to serialize parameter to")
 	ErrTooShortToParse         = errors.New("too short to decode as parameter")
 	ErrNotImplemented          = errors.New("not implemented")
+	ErrNotSupported            = errors.New("request not supported by the node")
+	ErrNotExecutable           = errors.New("request not executable by the node")
+	ErrUnknownResult           = errors.New("unknown result code")
+	ErrInvalidProfile          = errors.New("invalid device profile")
+	ErrInvalidNode             = errors.New("invalid node number")
+	ErrInvalidFrame            = errors.New("invalid frame")
+	ErrCyclicOutOfOrder        = errors.New("cyclic block out of order")
+	ErrCyclicIncomplete        = errors.New("cyclic blocks missing")
+	ErrCyclicMismatch          = errors.New("cyclic block of another transmission")
+	ErrOutOfArea               = errors.New("address out of the area")
+	ErrConnClosed              = errors.New("use of closed FL-net connection")
 )
+
+// ParseError is an error on decoding a frame, which tells where the frame deviates.
+// Offset is the position in the frame of Field, and Err is the cause, which can be
+// checked with errors.Is against ErrTooShortToParse or the other sentinel errors.
+type ParseError struct {
+	TCD    uint16
+	Offset int
+	Field  string
+	Err    error
+}
+
+// Error returns the error message.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to decode FLnet: TCD %d, offset %d, field %s: %v", e.TCD, e.Offset, e.Field, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestParseError(t *testing.T) {
+	cases := []struct {
+		description string
+		frame       flnet.FLnet
+		length      int
+		corrupt     func(b []byte)
+		tcd         uint16
+		offset      int
+		field       string
+		err         error
+	}{
+		{
+			description: "truncated header",
+			frame:       flnet.NewToken(),
+			length:      41,
+			tcd:         0,
+			offset:      41,
+			field:       "TCD",
+			err:         flnet.ErrTooShortToParse,
+		},
+		{
+			description: "truncated trigger",
+			frame:       flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."),
+			length:      76,
+			tcd:         flnet.TCDTrigger,
+			offset:      76,
+			field:       "VDN",
+			err:         flnet.ErrTooShortToParse,
+		},
+		{
+			description: "truncated network parameter",
+			frame:       flnet.NewNetworkParameterReadResponse(2, 1, 0, 0, &flnet.NetworkParameter{}),
+			length:      104,
+			tcd:         flnet.TCDNetworkParameterReadRequest,
+			offset:      104,
+			field:       "network parameter",
+			err:         flnet.ErrTooShortToParse,
+		},
+		{
+			description: "odd word data",
+			frame:       flnet.NewWordBlockReadResponse(2, 1, 0, 0, 0, []uint16{1, 2}),
+			length:      67,
+			tcd:         flnet.TCDWordBlockReadRequest,
+			offset:      66,
+			field:       "data",
+			err:         flnet.ErrInvalidFrame,
+		},
+		{
+			description: "malformed profile",
+			frame:       newTestProfileReadResponse(2, 1, 0, 0),
+			corrupt: func(b []byte) {
+				b[64] = 0x31 // SET instead of SEQUENCE
+			},
+			tcd:    flnet.TCDProfileReadRequest,
+			offset: 64,
+			field:  "device profile",
+			err:    flnet.ErrInvalidProfile,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			b, err := c.frame.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.length > 0 {
+				b = b[:c.length]
+			}
+			if c.corrupt != nil {
+				c.corrupt(b)
+			}
+
+			_, err = flnet.Parse(b)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("got %v, want %v", err, c.err)
+			}
+			var pe *flnet.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("got %T, want *flnet.ParseError", err)
+			}
+			if got, want := pe.TCD, c.tcd; got != want {
+				t.Errorf("TCD: got %d, want %d", got, want)
+			}
+			if got, want := pe.Offset, c.offset; got != want {
+				t.Errorf("Offset: got %d, want %d", got, want)
+			}
+			if got, want := pe.Field, c.field; got != want {
+				t.Errorf("Field: got %s, want %s", got, want)
+			}
+		})
+	}
+}
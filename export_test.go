@@ -0,0 +1,13 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import "time"
+
+// SetMessageReadDeadline sets the read deadline of the message socket of c,
+// to cause an error in reading it.
+func SetMessageReadDeadline(c *Conn, t time.Time) error {
+	return c.conns[socket(TCDByteBlockReadRequest)].SetReadDeadline(t)
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tcdNames is the names of the TCDs of the built-in frame types.
+// A request and its response share the name.
+var tcdNames = map[uint16]string{
+	TCDToken:                        "Token",
+	TCDCyclic:                       "Cyclic",
+	TCDParticipationRequest:         "ParticipationRequest",
+	TCDByteBlockReadRequest:         "ByteBlockRead",
+	TCDByteBlockWriteRequest:        "ByteBlockWrite",
+	TCDWordBlockReadRequest:         "WordBlockRead",
+	TCDWordBlockWriteRequest:        "WordBlockWrite",
+	TCDNetworkParameterReadRequest:  "NetworkParameterRead",
+	TCDNetworkParameterWriteRequest: "NetworkParameterWrite",
+	TCDStopCommandRequest:           "StopCommand",
+	TCDOperationCommandRequest:      "OperationCommand",
+	TCDProfileReadRequest:           "ProfileRead",
+	TCDTrigger:                      "Trigger",
+	TCDLogDataReadRequest:           "LogDataRead",
+	TCDLogDataClearRequest:          "LogDataClear",
+	TCDMessageReturnRequest:         "MessageReturn",
+}
+
+// tcdName returns the name of the TCD, such as "ByteBlockRead".
+// The TCDs of the transparent messages and the unknown ones are named
+// with their value, such as "Transparent(1000)".
+func tcdName(tcd uint16) string {
+	if name, ok := tcdNames[tcd]; ok {
+		return name
+	}
+	if tcd <= TCDTransparentMax {
+		return fmt.Sprintf("Transparent(%d)", tcd)
+	}
+	return fmt.Sprintf("Unknown(%d)", tcd)
+}
+
+// mctlNames is the names of the flags in M_CTL, from the most significant bit.
+var mctlNames = []struct {
+	flag uint32
+	name string
+}{
+	{mctlBCT, "BCT"},
+	{mctlPPT, "PPT"},
+	{mctlRPL, "RPL"},
+}
+
+// mctlFlags returns the names of the flags set in mctl.
+// The bits which are not defined are returned as a hexadecimal value.
+func mctlFlags(mctl uint32) []string {
+	flags := []string{}
+	for _, n := range mctlNames {
+		if mctl&n.flag != 0 {
+			flags = append(flags, n.name)
+			mctl &^= n.flag
+		}
+	}
+	if mctl != 0 {
+		flags = append(flags, fmt.Sprintf("0x%08x", mctl))
+	}
+	return flags
+}
+
+// mctlString returns M_CTL as the names of the flags joined with "|".
+func mctlString(mctl uint32) string {
+	if mctl == 0 {
+		return "0"
+	}
+	return strings.Join(mctlFlags(mctl), "|")
+}
+
+// nodeString returns SA or DA as the node number, or as a hexadecimal value
+// if it is not the conventional value for a node.
+func nodeString(a uint32) string {
+	if n := uint8(a); nodeAddress(n) == a {
+		return strconv.Itoa(int(n))
+	}
+	return fmt.Sprintf("0x%08x", a)
+}
+
+// String returns the TCD, the nodes, M_CTL, the sequence numbers and TFL
+// of the header in a line.
+func (h *FALinkHeader) String() string {
+	return fmt.Sprintf("TCD=%s SA=%s DA=%s M_CTL=%s V_SEQ=%d SEQ=%d TFL=%d",
+		tcdName(h.TCD), nodeString(h.SA), nodeString(h.DA), mctlString(h.MCTL), h.VSeq, h.Seq, h.TFL,
+	)
+}
+
+// areasString returns the cyclic areas and the block numbers in h.
+func areasString(h *FALinkHeader) string {
+	return fmt.Sprintf("C_AD1=0x%04x C_SZ1=%d C_AD2=0x%04x C_SZ2=%d CBN=%d TBN=%d",
+		h.CAD1, h.CSZ1, h.CAD2, h.CSZ2, h.CBN, h.TBN,
+	)
+}
+
+// String returns the header, the areas and the cyclic data of the token in a line.
+func (t *Token) String() string {
+	s := t.Header.String() + " " + areasString(t.Header)
+	if len(t.Data) > 0 {
+		s += fmt.Sprintf(" Data=%x", t.Data)
+	}
+	return s
+}
+
+// String returns the header, the areas and the cyclic data of the frame in a line.
+func (c *Cyclic) String() string {
+	return fmt.Sprintf("%s %s Data=%x", c.Header, areasString(c.Header), c.Data)
+}
+
+// String returns the header and the node information of the frame in a line.
+func (p *ParticipationHeader) String() string {
+	return fmt.Sprintf("%s NDN=%q VDN=%q MSN=%q",
+		p.Header, nodeInfo(p.NDN), nodeInfo(p.VDN), nodeInfo(p.MSN),
+	)
+}
+
+// nodeInfo returns the node information without the padding spaces.
+func nodeInfo(b [10]byte) string {
+	return strings.TrimRight(string(b[:]), " ")
+}
+
+// messageString returns the header and the message address of the frame.
+// M_RLT is added if the frame is a response.
+func messageString(h *FALinkHeader) string {
+	s := fmt.Sprintf("%s M_ADD=0x%08x M_SZ=%d", h, h.MADD, h.MSZ)
+	if h.RPL() {
+		s += fmt.Sprintf(" M_RLT=%d", h.MRLT)
+	}
+	return s
+}
+
+// String returns the header and the data of the message in a line.
+func (m *Message) String() string {
+	s := messageString(m.Header)
+	if len(m.Data) > 0 {
+		s += fmt.Sprintf(" Data=%x", m.Data)
+	}
+	return s
+}
+
+// String returns the header and the words of the message in a line.
+func (w *WordMessage) String() string {
+	s := messageString(w.Header)
+	if len(w.Data) > 0 {
+		s += fmt.Sprintf(" Data=%04x", w.Data)
+	}
+	return s
+}
+
+// String returns the header and the network parameter of the message in a line.
+func (n *NetworkParameterMessage) String() string {
+	return fmt.Sprintf("%s Parameter=%+v", messageString(n.Header), n.Parameter)
+}
+
+// String returns the header and the device profile of the frame in a line.
+func (p *ProfileReadResponse) String() string {
+	return fmt.Sprintf("%s Profile=%+v", messageString(p.Header), p.Profile)
+}
+
+// String returns the header and the log data of the frame in a line.
+func (l *LogDataReadResponse) String() string {
+	return fmt.Sprintf("%s Log=%+v", messageString(l.Header), l.Log)
+}
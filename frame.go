@@ -0,0 +1,179 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+// Frame is implemented by all the built-in frame types, in addition to FLnet.
+// It gives access to the common FA Link header without knowing the concrete
+// type of the frame returned by Parse.
+//
+// The header is returned by LinkHeader, since the frames already expose it
+// as the Header field.
+type Frame interface {
+	FLnet
+	LinkHeader() *FALinkHeader
+	TCD() uint16
+	IsResponse() bool
+	SourceNode() uint8
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (t *Token) LinkHeader() *FALinkHeader {
+	return t.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (t *Token) TCD() uint16 {
+	return t.Header.TCD
+}
+
+// IsResponse reports whether RPL is set in M_CTL.
+func (t *Token) IsResponse() bool {
+	return t.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (t *Token) SourceNode() uint8 {
+	return t.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (c *Cyclic) LinkHeader() *FALinkHeader {
+	return c.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (c *Cyclic) TCD() uint16 {
+	return c.Header.TCD
+}
+
+// IsResponse reports whether RPL is set in M_CTL.
+func (c *Cyclic) IsResponse() bool {
+	return c.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (c *Cyclic) SourceNode() uint8 {
+	return c.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (p *ParticipationHeader) LinkHeader() *FALinkHeader {
+	return p.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (p *ParticipationHeader) TCD() uint16 {
+	return p.Header.TCD
+}
+
+// IsResponse reports whether RPL is set in M_CTL.
+func (p *ParticipationHeader) IsResponse() bool {
+	return p.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (p *ParticipationHeader) SourceNode() uint8 {
+	return p.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (m *Message) LinkHeader() *FALinkHeader {
+	return m.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (m *Message) TCD() uint16 {
+	return m.Header.TCD
+}
+
+// IsResponse reports whether the message is a response, which is set in RPL of M_CTL.
+func (m *Message) IsResponse() bool {
+	return m.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (m *Message) SourceNode() uint8 {
+	return m.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (w *WordMessage) LinkHeader() *FALinkHeader {
+	return w.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (w *WordMessage) TCD() uint16 {
+	return w.Header.TCD
+}
+
+// IsResponse reports whether the message is a response, which is set in RPL of M_CTL.
+func (w *WordMessage) IsResponse() bool {
+	return w.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (w *WordMessage) SourceNode() uint8 {
+	return w.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (n *NetworkParameterMessage) LinkHeader() *FALinkHeader {
+	return n.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (n *NetworkParameterMessage) TCD() uint16 {
+	return n.Header.TCD
+}
+
+// IsResponse reports whether the message is a response, which is set in RPL of M_CTL.
+func (n *NetworkParameterMessage) IsResponse() bool {
+	return n.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (n *NetworkParameterMessage) SourceNode() uint8 {
+	return n.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (p *ProfileReadResponse) LinkHeader() *FALinkHeader {
+	return p.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (p *ProfileReadResponse) TCD() uint16 {
+	return p.Header.TCD
+}
+
+// IsResponse reports whether the message is a response, which is set in RPL of M_CTL.
+func (p *ProfileReadResponse) IsResponse() bool {
+	return p.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (p *ProfileReadResponse) SourceNode() uint8 {
+	return p.Header.SourceNode()
+}
+
+// LinkHeader returns the FA Link header of the frame.
+func (l *LogDataReadResponse) LinkHeader() *FALinkHeader {
+	return l.Header
+}
+
+// TCD returns the transaction code of the frame.
+func (l *LogDataReadResponse) TCD() uint16 {
+	return l.Header.TCD
+}
+
+// IsResponse reports whether the message is a response, which is set in RPL of M_CTL.
+func (l *LogDataReadResponse) IsResponse() bool {
+	return l.Header.RPL()
+}
+
+// SourceNode returns the node number of the sender.
+func (l *LogDataReadResponse) SourceNode() uint8 {
+	return l.Header.SourceNode()
+}
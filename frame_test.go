@@ -0,0 +1,57 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestFrame(t *testing.T) {
+	for _, v := range validFrames() {
+		b, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%T: %v", v, err)
+		}
+
+		parsed, err := flnet.Parse(b)
+		if err != nil {
+			t.Fatalf("%T: %v", v, err)
+		}
+		f, ok := parsed.(flnet.Frame)
+		if !ok {
+			t.Errorf("%T does not implement Frame", parsed)
+			continue
+		}
+
+		h := v.(flnet.Frame).LinkHeader()
+		if got, want := f.TCD(), h.TCD; got != want {
+			t.Errorf("%T: TCD() = %d, want %d", f, got, want)
+		}
+		if got, want := f.IsResponse(), h.RPL(); got != want {
+			t.Errorf("%T: IsResponse() = %v, want %v", f, got, want)
+		}
+		if got, want := f.SourceNode(), h.SourceNode(); got != want {
+			t.Errorf("%T: SourceNode() = %d, want %d", f, got, want)
+		}
+		if got := f.LinkHeader(); *got != *h {
+			t.Errorf("%T: LinkHeader() = %+v, want %+v", f, got, h)
+		}
+	}
+
+	unknown := make([]byte, 64)
+	unknown[40], unknown[41] = 0xfa, 0x00 // TCD 64000
+	g, err := flnet.Parse(unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.(*flnet.Generic); !ok {
+		t.Errorf("got %T, want *flnet.Generic", g)
+	}
+	if _, ok := g.(flnet.Frame); !ok {
+		t.Errorf("%T does not implement Frame", g)
+	}
+}
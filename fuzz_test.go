@@ -0,0 +1,34 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package flnet_test
+
+import (
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+// FuzzParse checks that Parse never panics. The corpus is seeded with the
+// valid frames, and with the malformed ones in testdata/fuzz/FuzzParse.
+func FuzzParse(f *testing.F) {
+	for _, frame := range validFrames() {
+		b, err := frame.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		frame, err := flnet.Parse(b)
+		if err != nil {
+			return
+		}
+		_, _ = frame.MarshalBinary()
+	})
+}
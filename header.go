@@ -25,6 +25,27 @@ const (
 	TCDOperationCommandRequest
 	TCDProfileReadRequest
 	TCDTrigger
+	TCDLogDataReadRequest
+	TCDLogDataClearRequest
+	TCDMessageReturnRequest
+)
+
+// TCDTransparentMax is the largest TCD of the transparent messages,
+// which are available for user applications from 0 to TCDTransparentMax.
+const TCDTransparentMax uint16 = 59999
+
+// M_CTL flags.
+const (
+	mctlBCT uint32 = 1 << 31
+	mctlPPT uint32 = 1 << 30
+	mctlRPL uint32 = 1 << 29
+)
+
+// MODE bit fields.
+const (
+	modeTokenMode    uint16 = 0x0001
+	modeMajorVersion uint16 = 0x00f0
+	modeMinorVersion uint16 = 0xff00
 )
 
 // FALinkHeader is a FL-net header.
@@ -97,11 +118,11 @@ func NewFALinkHeader(
 	return &FALinkHeader{
 		HType:    htype,
 		TFL:      tfl,
-		SA:       0x00010000 | uint32(sna),
-		DA:       0x00010000 | uint32(dna),
+		SA:       nodeAddress(sna),
+		DA:       nodeAddress(dna),
 		VSeq:     vseq,
 		Seq:      seq,
-		MCTL:     uint32((utils.BoolToUint(bct) << 31) + (utils.BoolToUint(ppt) << 30)),
+		MCTL:     uint32((utils.BoolToUint(bct) << 31) + (utils.BoolToUint(ppt) << 30) + (utils.BoolToUint(rpl) << 29)),
 		ULS:      uls,
 		MSZ:      msz,
 		MADD:     madd,
@@ -116,6 +137,7 @@ func NewFALinkHeader(
 		CSZ2:     csz2,
 		Mode:     uint16((minver << 8) + (majver << 4) + utils.BoolToUint(tokmode)),
 		PType:    ptype,
+		Pri:      pri,
 		CBN:      cbn,
 		TBN:      tbn,
 		BSize:    bsize,
@@ -125,6 +147,264 @@ func NewFALinkHeader(
 	}
 }
 
+// headerFields are the offsets and the names of the fields of FALinkHeader.
+var headerFields = []struct {
+	offset int
+	name   string
+}{
+	{0, "H_TYPE"},
+	{4, "TFL"},
+	{8, "SA"},
+	{12, "DA"},
+	{16, "V_SEQ"},
+	{20, "SEQ"},
+	{24, "M_CTL"},
+	{28, "ULS"},
+	{30, "M_SZ"},
+	{32, "M_ADD"},
+	{36, "MFT"},
+	{37, "M_RLT"},
+	{38, "reserved"},
+	{40, "TCD"},
+	{42, "VER"},
+	{44, "C_AD1"},
+	{46, "C_SZ1"},
+	{48, "C_AD2"},
+	{50, "C_SZ2"},
+	{52, "MODE"},
+	{54, "P_TYPE"},
+	{55, "PRI"},
+	{56, "CBN"},
+	{57, "TBN"},
+	{58, "BSIZE"},
+	{60, "LKS"},
+	{61, "TW"},
+	{62, "RCT"},
+}
+
+// headerField returns the name of the field of FALinkHeader at offset.
+func headerField(offset int) string {
+	name := headerFields[0].name
+	for _, f := range headerFields {
+		if f.offset > offset {
+			break
+		}
+		name = f.name
+	}
+	return name
+}
+
+// hTypeFACN is the H_TYPE of FL-net frames.
+var hTypeFACN = [4]byte{0x46, 0x41, 0x43, 0x4e}
+
+// HeaderOption is an option of NewHeader.
+type HeaderOption func(h *FALinkHeader)
+
+// NewHeader creates a new FALinkHeader with the given options.
+// Without options, H_TYPE is "FACN", TFL and BSIZE are the length of the header only,
+// MODE is the token mode of major version 3, P_TYPE is 0x80, CBN and TBN are 1
+// and TW is 50 ms.
+func NewHeader(opts ...HeaderOption) *FALinkHeader {
+	h := &FALinkHeader{
+		HType: hTypeFACN,
+		TFL:   64,
+		SA:    nodeAddress(0),
+		DA:    nodeAddress(0),
+		Mode:  uint16((3 << 4) + 1),
+		PType: 0x80,
+		CBN:   1,
+		TBN:   1,
+		BSize: 64,
+		TW:    0x32,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// WithHType sets H_TYPE.
+func WithHType(htype [4]byte) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.HType = htype
+	}
+}
+
+// WithSource sets SA to the node number sna.
+func WithSource(sna uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.SA = nodeAddress(sna)
+	}
+}
+
+// WithDest sets DA to the node number dna.
+func WithDest(dna uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.DA = nodeAddress(dna)
+	}
+}
+
+// WithVSeq sets V_SEQ.
+func WithVSeq(vseq uint32) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.VSeq = vseq
+	}
+}
+
+// WithSeq sets SEQ.
+func WithSeq(seq uint32) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.Seq = seq
+	}
+}
+
+// WithBroadcast sets BCT of M_CTL.
+func WithBroadcast() HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MCTL |= mctlBCT
+	}
+}
+
+// WithPointToPoint sets PPT of M_CTL.
+func WithPointToPoint() HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MCTL |= mctlPPT
+	}
+}
+
+// WithResponse sets RPL of M_CTL.
+func WithResponse() HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MCTL |= mctlRPL
+	}
+}
+
+// WithULS sets ULS.
+func WithULS(uls uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.ULS = uls
+	}
+}
+
+// WithMessage sets M_ADD and M_SZ.
+func WithMessage(madd uint32, msz uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MADD = madd
+		h.MSZ = msz
+	}
+}
+
+// WithMFT sets MFT.
+func WithMFT(mft uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MFT = mft
+	}
+}
+
+// WithMRLT sets M_RLT.
+func WithMRLT(mrlt uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.MRLT = mrlt
+	}
+}
+
+// WithTCD sets TCD.
+func WithTCD(tcd uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.TCD = tcd
+	}
+}
+
+// WithVer sets VER.
+func WithVer(ver uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.Ver = ver
+	}
+}
+
+// WithArea1 sets C_AD1 and C_SZ1.
+func WithArea1(cad1, csz1 uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.CAD1 = cad1
+		h.CSZ1 = csz1
+	}
+}
+
+// WithArea2 sets C_AD2 and C_SZ2.
+func WithArea2(cad2, csz2 uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.CAD2 = cad2
+		h.CSZ2 = csz2
+	}
+}
+
+// WithMode sets MODE.
+func WithMode(minver, majver uint, tokmode bool) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.Mode = uint16((minver << 8) + (majver << 4) + utils.BoolToUint(tokmode))
+	}
+}
+
+// WithPType sets P_TYPE.
+func WithPType(ptype uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.PType = ptype
+	}
+}
+
+// WithPri sets PRI.
+func WithPri(pri uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.Pri = pri
+	}
+}
+
+// WithBlock sets CBN and TBN.
+func WithBlock(cbn, tbn uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.CBN = cbn
+		h.TBN = tbn
+	}
+}
+
+// WithPayloadLen sets TFL and BSIZE to the length of the header and n bytes of payload.
+func WithPayloadLen(n int) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.TFL = uint32(h.MarshalLen() + n)
+		h.BSize = uint16(h.MarshalLen() + n)
+	}
+}
+
+// WithTotalLen sets TFL to the length of the header and n bytes of data in all
+// the blocks, which differs from the one set by WithPayloadLen when the data
+// are split into blocks or not carried in the frame.
+func WithTotalLen(n int) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.TFL = uint32(h.MarshalLen() + n)
+	}
+}
+
+// WithLKS sets LKS.
+func WithLKS(lks uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.LKS = lks
+	}
+}
+
+// WithTW sets TW.
+func WithTW(tw uint8) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.TW = tw
+	}
+}
+
+// WithRCT sets RCT.
+func WithRCT(rct uint16) HeaderOption {
+	return func(h *FALinkHeader) {
+		h.RCT = rct
+	}
+}
+
 // MarshalBinary returns the byte sequence generated from a FALinkHeader instance.
 func (h *FALinkHeader) MarshalBinary() ([]byte, error) {
 	b := make([]byte, h.MarshalLen())
@@ -189,8 +469,8 @@ func ParseHeader(b []byte) (*FALinkHeader, error) {
 
 // UnmarshalBinary sets the values retrieved from byte sequence in a FL-net common header.
 func (h *FALinkHeader) UnmarshalBinary(b []byte) error {
-	if len(b) < 64 {
-		return ErrTooShortToParse
+	if len(b) < h.MarshalLen() {
+		return errTooShort(headerField(len(b)), h.MarshalLen(), b)
 	}
 
 	copy(h.HType[:], b[:4])
@@ -224,3 +504,86 @@ func (h *FALinkHeader) UnmarshalBinary(b []byte) error {
 
 	return nil
 }
+
+// BCT reports whether BCT (broadcast) of M_CTL is set.
+func (h *FALinkHeader) BCT() bool {
+	return h.MCTL&mctlBCT != 0
+}
+
+// SetBCT sets or clears BCT (broadcast) of M_CTL.
+func (h *FALinkHeader) SetBCT(v bool) {
+	h.MCTL = setFlag(h.MCTL, mctlBCT, v)
+}
+
+// PPT reports whether PPT (point to point) of M_CTL is set.
+func (h *FALinkHeader) PPT() bool {
+	return h.MCTL&mctlPPT != 0
+}
+
+// SetPPT sets or clears PPT (point to point) of M_CTL.
+func (h *FALinkHeader) SetPPT(v bool) {
+	h.MCTL = setFlag(h.MCTL, mctlPPT, v)
+}
+
+// RPL reports whether RPL (response) of M_CTL is set.
+func (h *FALinkHeader) RPL() bool {
+	return h.MCTL&mctlRPL != 0
+}
+
+// SetRPL sets or clears RPL (response) of M_CTL.
+func (h *FALinkHeader) SetRPL(v bool) {
+	h.MCTL = setFlag(h.MCTL, mctlRPL, v)
+}
+
+// TokenMode reports whether the token mode bit of MODE is set.
+func (h *FALinkHeader) TokenMode() bool {
+	return h.Mode&modeTokenMode != 0
+}
+
+// SetTokenMode sets or clears the token mode bit of MODE.
+func (h *FALinkHeader) SetTokenMode(v bool) {
+	h.Mode = uint16(setFlag(uint32(h.Mode), uint32(modeTokenMode), v))
+}
+
+// MajorVersion returns the major version of the protocol in MODE.
+func (h *FALinkHeader) MajorVersion() uint {
+	return uint(h.Mode&modeMajorVersion) >> 4
+}
+
+// MinorVersion returns the minor version of the protocol in MODE.
+func (h *FALinkHeader) MinorVersion() uint {
+	return uint(h.Mode&modeMinorVersion) >> 8
+}
+
+// SetVersion sets the minor and major version of the protocol in MODE.
+func (h *FALinkHeader) SetVersion(minver, majver uint) {
+	h.Mode = h.Mode&modeTokenMode | uint16(minver<<8)&modeMinorVersion | uint16(majver<<4)&modeMajorVersion
+}
+
+// UpperLayerStatus returns ULS.
+func (h *FALinkHeader) UpperLayerStatus() UpperLayerStatus {
+	return UpperLayerStatus(h.ULS)
+}
+
+// SetUpperLayerStatus sets ULS.
+func (h *FALinkHeader) SetUpperLayerStatus(uls UpperLayerStatus) {
+	h.ULS = uint16(uls)
+}
+
+// LinkStatus returns LKS.
+func (h *FALinkHeader) LinkStatus() LinkStatus {
+	return LinkStatus(h.LKS)
+}
+
+// SetLinkStatus sets LKS.
+func (h *FALinkHeader) SetLinkStatus(lks LinkStatus) {
+	h.LKS = uint8(lks)
+}
+
+// setFlag returns v with the flag set or cleared.
+func setFlag(v, flag uint32, set bool) uint32 {
+	if set {
+		return v | flag
+	}
+	return v &^ flag
+}
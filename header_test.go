@@ -80,3 +80,137 @@ func TestMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHeader(t *testing.T) {
+	cases := []struct {
+		description string
+		got         *flnet.FALinkHeader
+		want        *flnet.FALinkHeader
+	}{
+		{
+			"default",
+			flnet.NewHeader(),
+			flnet.NewFALinkHeader(
+				[4]byte{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
+				0x40,                            // TFL
+				0,                               // SA
+				0,                               // DA
+				0,                               // V_SEQ
+				0,                               // SEQ
+				false, false, false,             // M_CTL
+				0, 0, // ULS, M_SZ
+				0,       // M_ADD
+				0, 0, 0, // MFT, M_RLT, reserved
+				0, 0, // TCD, VER
+				0, 0, // C_AD1, C_SZ1
+				0, 0, // C_AD2, C_SZ2
+				0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
+				1, 1, 0x40, // CBN, TBN, BSIZE
+				0, 0x32, 0, // LKS, TW, RCT
+			),
+		},
+		{
+			"with options",
+			flnet.NewHeader(
+				flnet.WithSource(1),
+				flnet.WithDest(0xff),
+				flnet.WithVSeq(2),
+				flnet.WithSeq(3),
+				flnet.WithBroadcast(),
+				flnet.WithULS(0x8000),
+				flnet.WithMessage(0x1000, 4),
+				flnet.WithMFT(0x0a),
+				flnet.WithMRLT(1),
+				flnet.WithTCD(flnet.TCDCyclic),
+				flnet.WithVer(1),
+				flnet.WithArea1(4, 4),
+				flnet.WithArea2(64, 64),
+				flnet.WithMode(1, 2, false),
+				flnet.WithPType(0x81),
+				flnet.WithPri(7),
+				flnet.WithBlock(2, 3),
+				flnet.WithPayloadLen(136),
+				flnet.WithLKS(0x80),
+				flnet.WithTW(0x40),
+				flnet.WithRCT(5),
+			),
+			flnet.NewFALinkHeader(
+				[4]byte{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
+				0xc8,                            // TFL
+				1,                               // SA
+				0xff,                            // DA
+				2,                               // V_SEQ
+				3,                               // SEQ
+				true, false, false,              // M_CTL
+				0x8000, 4, // ULS, M_SZ
+				0x1000,     // M_ADD
+				0x0a, 1, 0, // MFT, M_RLT, reserved
+				flnet.TCDCyclic, 1, // TCD, VER
+				4, 4, // C_AD1, C_SZ1
+				64, 64, // C_AD2, C_SZ2
+				1, 2, false, 0x81, 7, // MODE, P_TYPE, PRI
+				2, 3, 0xc8, // CBN, TBN, BSIZE
+				0x80, 0x40, 5, // LKS, TW, RCT
+			),
+		},
+		{
+			"with total length",
+			flnet.NewHeader(
+				flnet.WithPayloadLen(0),
+				flnet.WithTotalLen(136),
+			),
+			func() *flnet.FALinkHeader {
+				h := flnet.NewHeader()
+				h.TFL = 0xc8
+				return h
+			}(),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if diff := cmp.Diff(c.want, c.got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHeaderBitFields(t *testing.T) {
+	h := flnet.NewHeader()
+
+	h.SetBCT(true)
+	h.SetRPL(true)
+	if got, want := h.MCTL, uint32(0xa0000000); got != want {
+		t.Errorf("M_CTL: got %#x, want %#x", got, want)
+	}
+	if !h.BCT() || h.PPT() || !h.RPL() {
+		t.Errorf("got BCT=%v PPT=%v RPL=%v", h.BCT(), h.PPT(), h.RPL())
+	}
+	h.SetBCT(false)
+	h.SetPPT(true)
+	if got, want := h.MCTL, uint32(0x60000000); got != want {
+		t.Errorf("M_CTL: got %#x, want %#x", got, want)
+	}
+
+	if !h.TokenMode() || h.MajorVersion() != 3 || h.MinorVersion() != 0 {
+		t.Errorf("got token mode=%v, version=%d.%d", h.TokenMode(), h.MajorVersion(), h.MinorVersion())
+	}
+	h.SetVersion(2, 1)
+	h.SetTokenMode(false)
+	if got, want := h.Mode, uint16(0x0210); got != want {
+		t.Errorf("MODE: got %#x, want %#x", got, want)
+	}
+	if h.TokenMode() || h.MajorVersion() != 1 || h.MinorVersion() != 2 {
+		t.Errorf("got token mode=%v, version=%d.%d", h.TokenMode(), h.MajorVersion(), h.MinorVersion())
+	}
+
+	h.SetUpperLayerStatus(flnet.NewUpperLayerStatus(true, false, 0x123))
+	if got, want := h.ULS, uint16(0x8123); got != want {
+		t.Errorf("ULS: got %#x, want %#x", got, want)
+	}
+	h.SetLinkStatus(flnet.LKSJoined | flnet.LKSCommonMemoryValid)
+	if got, want := h.LKS, uint8(0xa0); got != want {
+		t.Errorf("LKS: got %#x, want %#x", got, want)
+	}
+}
@@ -0,0 +1,606 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// tcdJSON is a TCD encoded as its name if it is of a built-in frame type,
+// or as its value otherwise.
+type tcdJSON uint16
+
+// MarshalJSON returns the name or the value of the TCD.
+func (t tcdJSON) MarshalJSON() ([]byte, error) {
+	if name, ok := tcdNames[uint16(t)]; ok {
+		return json.Marshal(name)
+	}
+	return json.Marshal(uint16(t))
+}
+
+// UnmarshalJSON sets the TCD from its name or value.
+func (t *tcdJSON) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		var v uint16
+		if err := json.Unmarshal(b, &v); err != nil {
+			return errors.Wrapf(ErrInvalidFrame, "TCD %s", b)
+		}
+		*t = tcdJSON(v)
+		return nil
+	}
+
+	for v, n := range tcdNames {
+		if n == name {
+			*t = tcdJSON(v)
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrInvalidFrame, "TCD %q", name)
+}
+
+// mctlJSON is M_CTL encoded as the list of the flags.
+type mctlJSON uint32
+
+// MarshalJSON returns the names of the flags in M_CTL.
+func (m mctlJSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mctlFlags(uint32(m)))
+}
+
+// UnmarshalJSON sets M_CTL from the names of the flags,
+// or the hexadecimal values of the undefined bits.
+func (m *mctlJSON) UnmarshalJSON(b []byte) error {
+	var flags []string
+	if err := json.Unmarshal(b, &flags); err != nil {
+		return err
+	}
+
+	var mctl uint32
+	for _, f := range flags {
+		if flag := mctlFlag(f); flag != 0 {
+			mctl |= flag
+			continue
+		}
+		v, err := strconv.ParseUint(f, 0, 32)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidFrame, "M_CTL flag %q", f)
+		}
+		mctl |= uint32(v)
+	}
+	*m = mctlJSON(mctl)
+	return nil
+}
+
+// mctlFlag returns the flag of M_CTL named name, or 0 if there is no such flag.
+func mctlFlag(name string) uint32 {
+	for _, n := range mctlNames {
+		if n.name == name {
+			return n.flag
+		}
+	}
+	return 0
+}
+
+// nodeJSON is SA or DA encoded as the node number, or as a hexadecimal string
+// if it is not the conventional value for a node.
+type nodeJSON uint32
+
+// MarshalJSON returns the node number or the hexadecimal value.
+func (n nodeJSON) MarshalJSON() ([]byte, error) {
+	if node := uint8(n); nodeAddress(node) == uint32(n) {
+		return json.Marshal(node)
+	}
+	return json.Marshal(fmt.Sprintf("0x%08x", uint32(n)))
+}
+
+// UnmarshalJSON sets SA or DA from the node number or the hexadecimal value.
+func (n *nodeJSON) UnmarshalJSON(b []byte) error {
+	var node uint8
+	if err := json.Unmarshal(b, &node); err == nil {
+		*n = nodeJSON(nodeAddress(node))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.Wrapf(ErrInvalidFrame, "node %s", b)
+	}
+	v, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidFrame, "node %q", s)
+	}
+	*n = nodeJSON(v)
+	return nil
+}
+
+// hexBytes is a byte slice encoded as a hexadecimal string.
+type hexBytes []byte
+
+// MarshalJSON returns the bytes as a hexadecimal string.
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+// UnmarshalJSON sets the bytes from a hexadecimal string.
+func (h *hexBytes) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	d, err := hex.DecodeString(s)
+	if err != nil {
+		return errors.Wrap(ErrInvalidFrame, err.Error())
+	}
+	if len(d) == 0 {
+		d = nil
+	}
+	*h = d
+	return nil
+}
+
+// textJSON is a string encoded as it is if it is printable ASCII, or as
+// its bytes in a hexadecimal string like {"hex":"8a303030"} otherwise,
+// since encoding/json replaces the bytes which are not valid UTF-8.
+type textJSON string
+
+// textHexJSON is the JSON representation of textJSON which is not printable.
+type textHexJSON struct {
+	Hex hexBytes `json:"hex"`
+}
+
+// MarshalJSON returns the string, or its bytes if it is not printable ASCII.
+func (t textJSON) MarshalJSON() ([]byte, error) {
+	for i := 0; i < len(t); i++ {
+		if t[i] < 0x20 || t[i] > 0x7e {
+			return json.Marshal(&textHexJSON{Hex: hexBytes(t)})
+		}
+	}
+	return json.Marshal(string(t))
+}
+
+// UnmarshalJSON sets the string from a string or its bytes.
+func (t *textJSON) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = textJSON(s)
+		return nil
+	}
+
+	var j textHexJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return errors.Wrapf(ErrInvalidFrame, "text %s", b)
+	}
+	*t = textJSON(j.Hex)
+	return nil
+}
+
+// headerJSON is the JSON representation of FALinkHeader.
+type headerJSON struct {
+	HType    textJSON `json:"h_type"`
+	TFL      uint32   `json:"tfl"`
+	SA       nodeJSON `json:"sa"`
+	DA       nodeJSON `json:"da"`
+	VSeq     uint32   `json:"v_seq"`
+	Seq      uint32   `json:"seq"`
+	MCTL     mctlJSON `json:"m_ctl"`
+	ULS      uint16   `json:"uls"`
+	MSZ      uint16   `json:"m_sz"`
+	MADD     uint32   `json:"m_add"`
+	MFT      uint8    `json:"mft"`
+	MRLT     uint8    `json:"m_rlt"`
+	Reserved uint16   `json:"reserved"`
+	TCD      tcdJSON  `json:"tcd"`
+	Ver      uint16   `json:"ver"`
+	CAD1     uint16   `json:"c_ad1"`
+	CSZ1     uint16   `json:"c_sz1"`
+	CAD2     uint16   `json:"c_ad2"`
+	CSZ2     uint16   `json:"c_sz2"`
+	Mode     uint16   `json:"mode"`
+	PType    uint8    `json:"p_type"`
+	Pri      uint8    `json:"pri"`
+	CBN      uint8    `json:"cbn"`
+	TBN      uint8    `json:"tbn"`
+	BSize    uint16   `json:"bsize"`
+	LKS      uint8    `json:"lks"`
+	TW       uint8    `json:"tw"`
+	RCT      uint16   `json:"rct"`
+}
+
+// MarshalJSON returns the JSON encoding of the header.
+// TCD is encoded as its name, SA and DA as the node numbers and
+// M_CTL as the list of its flags. H_TYPE which is not printable ASCII
+// is encoded as its bytes.
+func (h *FALinkHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&headerJSON{
+		HType:    textJSON(h.HType[:]),
+		TFL:      h.TFL,
+		SA:       nodeJSON(h.SA),
+		DA:       nodeJSON(h.DA),
+		VSeq:     h.VSeq,
+		Seq:      h.Seq,
+		MCTL:     mctlJSON(h.MCTL),
+		ULS:      h.ULS,
+		MSZ:      h.MSZ,
+		MADD:     h.MADD,
+		MFT:      h.MFT,
+		MRLT:     h.MRLT,
+		Reserved: h.Reserved,
+		TCD:      tcdJSON(h.TCD),
+		Ver:      h.Ver,
+		CAD1:     h.CAD1,
+		CSZ1:     h.CSZ1,
+		CAD2:     h.CAD2,
+		CSZ2:     h.CSZ2,
+		Mode:     h.Mode,
+		PType:    h.PType,
+		Pri:      h.Pri,
+		CBN:      h.CBN,
+		TBN:      h.TBN,
+		BSize:    h.BSize,
+		LKS:      h.LKS,
+		TW:       h.TW,
+		RCT:      h.RCT,
+	})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a header.
+// The fields missing in b are left unchanged.
+func (h *FALinkHeader) UnmarshalJSON(b []byte) error {
+	j := &headerJSON{
+		HType:    textJSON(h.HType[:]),
+		TFL:      h.TFL,
+		SA:       nodeJSON(h.SA),
+		DA:       nodeJSON(h.DA),
+		VSeq:     h.VSeq,
+		Seq:      h.Seq,
+		MCTL:     mctlJSON(h.MCTL),
+		ULS:      h.ULS,
+		MSZ:      h.MSZ,
+		MADD:     h.MADD,
+		MFT:      h.MFT,
+		MRLT:     h.MRLT,
+		Reserved: h.Reserved,
+		TCD:      tcdJSON(h.TCD),
+		Ver:      h.Ver,
+		CAD1:     h.CAD1,
+		CSZ1:     h.CSZ1,
+		CAD2:     h.CAD2,
+		CSZ2:     h.CSZ2,
+		Mode:     h.Mode,
+		PType:    h.PType,
+		Pri:      h.Pri,
+		CBN:      h.CBN,
+		TBN:      h.TBN,
+		BSize:    h.BSize,
+		LKS:      h.LKS,
+		TW:       h.TW,
+		RCT:      h.RCT,
+	}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if len(j.HType) != len(h.HType) {
+		return errors.Wrapf(ErrInvalidFrame, "H_TYPE %q", j.HType)
+	}
+
+	copy(h.HType[:], j.HType)
+	h.TFL = j.TFL
+	h.SA = uint32(j.SA)
+	h.DA = uint32(j.DA)
+	h.VSeq = j.VSeq
+	h.Seq = j.Seq
+	h.MCTL = uint32(j.MCTL)
+	h.ULS = j.ULS
+	h.MSZ = j.MSZ
+	h.MADD = j.MADD
+	h.MFT = j.MFT
+	h.MRLT = j.MRLT
+	h.Reserved = j.Reserved
+	h.TCD = uint16(j.TCD)
+	h.Ver = j.Ver
+	h.CAD1 = j.CAD1
+	h.CSZ1 = j.CSZ1
+	h.CAD2 = j.CAD2
+	h.CSZ2 = j.CSZ2
+	h.Mode = j.Mode
+	h.PType = j.PType
+	h.Pri = j.Pri
+	h.CBN = j.CBN
+	h.TBN = j.TBN
+	h.BSize = j.BSize
+	h.LKS = j.LKS
+	h.TW = j.TW
+	h.RCT = j.RCT
+
+	return nil
+}
+
+// ParseJSON decodes the JSON encoding of a frame.
+// The frame type is chosen by the TCD and RPL in the header as Parse does,
+// but the decoders registered by RegisterDecoder are not used.
+func ParseJSON(b []byte) (FLnet, error) {
+	var j struct {
+		Header *struct {
+			TCD  tcdJSON  `json:"tcd"`
+			MCTL mctlJSON `json:"m_ctl"`
+		} `json:"header"`
+	}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return nil, err
+	}
+	if j.Header == nil {
+		return nil, errors.Wrap(ErrInvalidFrame, "no header")
+	}
+
+	f := newFrame(uint16(j.Header.TCD), uint32(j.Header.MCTL)&mctlRPL != 0)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// payloadJSON is the JSON representation of the frames with a payload.
+type payloadJSON struct {
+	Header *FALinkHeader `json:"header"`
+	Data   hexBytes      `json:"data,omitempty"`
+}
+
+// header returns h, or a new header if h is nil.
+func header(h *FALinkHeader) *FALinkHeader {
+	if h == nil {
+		return &FALinkHeader{}
+	}
+	return h
+}
+
+// MarshalJSON returns the JSON encoding of the token.
+// The cyclic data is encoded as a hexadecimal string.
+func (t *Token) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&payloadJSON{Header: t.Header, Data: t.Data})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a token.
+func (t *Token) UnmarshalJSON(b []byte) error {
+	j := &payloadJSON{Header: header(t.Header), Data: t.Data}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	t.Header, t.Data = j.Header, j.Data
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the cyclic frame.
+// The cyclic data is encoded as a hexadecimal string.
+func (c *Cyclic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&payloadJSON{Header: c.Header, Data: c.Data})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a cyclic frame.
+func (c *Cyclic) UnmarshalJSON(b []byte) error {
+	j := &payloadJSON{Header: header(c.Header), Data: c.Data}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	c.Header, c.Data = j.Header, j.Data
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the message.
+// The message data is encoded as a hexadecimal string.
+func (m *Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&payloadJSON{Header: m.Header, Data: m.Data})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a message.
+func (m *Message) UnmarshalJSON(b []byte) error {
+	j := &payloadJSON{Header: header(m.Header), Data: m.Data}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	m.Header, m.Data = j.Header, j.Data
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the word message.
+// The words are encoded as a hexadecimal string in big endian.
+func (w *WordMessage) MarshalJSON() ([]byte, error) {
+	d := make([]byte, len(w.Data)*2)
+	for i, v := range w.Data {
+		binary.BigEndian.PutUint16(d[i*2:], v)
+	}
+	return json.Marshal(&payloadJSON{Header: w.Header, Data: d})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a word message.
+func (w *WordMessage) UnmarshalJSON(b []byte) error {
+	j := &payloadJSON{Header: header(w.Header)}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if len(j.Data)%2 != 0 {
+		return errors.Wrapf(ErrInvalidFrame, "odd length of word data %d", len(j.Data))
+	}
+
+	w.Header = j.Header
+	w.Data = nil
+	for i := 0; i < len(j.Data); i += 2 {
+		w.Data = append(w.Data, binary.BigEndian.Uint16(j.Data[i:]))
+	}
+	return nil
+}
+
+// participationJSON is the JSON representation of ParticipationHeader.
+// The node information is encoded without the padding spaces.
+type participationJSON struct {
+	Header            *FALinkHeader `json:"header"`
+	NodeName          textJSON      `json:"node_name"`
+	VendorCode        textJSON      `json:"vendor_code"`
+	ManufacturerModel textJSON      `json:"manufacturer_model"`
+	Reserve           uint16        `json:"reserve,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the frame.
+func (p *ParticipationHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&participationJSON{
+		Header:            p.Header,
+		NodeName:          textJSON(nodeInfo(p.NDN)),
+		VendorCode:        textJSON(nodeInfo(p.VDN)),
+		ManufacturerModel: textJSON(nodeInfo(p.MSN)),
+		Reserve:           p.Reserve,
+	})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of the frame.
+// The node information is padded with spaces.
+func (p *ParticipationHeader) UnmarshalJSON(b []byte) error {
+	j := &participationJSON{
+		Header:            header(p.Header),
+		NodeName:          textJSON(nodeInfo(p.NDN)),
+		VendorCode:        textJSON(nodeInfo(p.VDN)),
+		ManufacturerModel: textJSON(nodeInfo(p.MSN)),
+		Reserve:           p.Reserve,
+	}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+
+	for _, n := range []struct {
+		dst  *[10]byte
+		name string
+		s    textJSON
+	}{
+		{&p.NDN, "node_name", j.NodeName},
+		{&p.VDN, "vendor_code", j.VendorCode},
+		{&p.MSN, "manufacturer_model", j.ManufacturerModel},
+	} {
+		if len(n.s) > len(n.dst) {
+			return errors.Wrapf(ErrInvalidFrame, "%s %q is longer than %d bytes", n.name, n.s, len(n.dst))
+		}
+		copy(n.dst[:], string(n.s)+strings.Repeat(" ", len(n.dst)-len(n.s)))
+	}
+	p.Header = j.Header
+	p.Reserve = j.Reserve
+	return nil
+}
+
+// networkParameter is NetworkParameter without its JSON methods.
+type networkParameter NetworkParameter
+
+// parameterJSON is the JSON representation of NetworkParameter,
+// whose names are encoded as textJSON.
+type parameterJSON struct {
+	*networkParameter
+	NodeName          textJSON `json:"node_name"`
+	VendorCode        textJSON `json:"vendor_code"`
+	ManufacturerModel textJSON `json:"manufacturer_model"`
+}
+
+// MarshalJSON returns the JSON encoding of the network parameter.
+// The names which are not printable ASCII are encoded as their bytes.
+func (p *NetworkParameter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&parameterJSON{
+		networkParameter:  (*networkParameter)(p),
+		NodeName:          textJSON(p.NodeName),
+		VendorCode:        textJSON(p.VendorCode),
+		ManufacturerModel: textJSON(p.ManufacturerModel),
+	})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a network parameter.
+func (p *NetworkParameter) UnmarshalJSON(b []byte) error {
+	j := &parameterJSON{
+		networkParameter:  (*networkParameter)(p),
+		NodeName:          textJSON(p.NodeName),
+		VendorCode:        textJSON(p.VendorCode),
+		ManufacturerModel: textJSON(p.ManufacturerModel),
+	}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	p.NodeName = string(j.NodeName)
+	p.VendorCode = string(j.VendorCode)
+	p.ManufacturerModel = string(j.ManufacturerModel)
+	return nil
+}
+
+// networkParameterJSON is the JSON representation of NetworkParameterMessage.
+type networkParameterJSON struct {
+	Header    *FALinkHeader     `json:"header"`
+	Parameter *NetworkParameter `json:"parameter,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the network parameter message.
+func (n *NetworkParameterMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&networkParameterJSON{Header: n.Header, Parameter: n.Parameter})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a network parameter message.
+func (n *NetworkParameterMessage) UnmarshalJSON(b []byte) error {
+	j := &networkParameterJSON{Header: header(n.Header), Parameter: n.Parameter}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if j.Parameter == nil {
+		return errors.Wrap(ErrInvalidFrame, "no parameter")
+	}
+	n.Header, n.Parameter = j.Header, j.Parameter
+	return nil
+}
+
+// profileReadJSON is the JSON representation of ProfileReadResponse.
+type profileReadJSON struct {
+	Header  *FALinkHeader  `json:"header"`
+	Profile *DeviceProfile `json:"profile,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the profile read response.
+func (p *ProfileReadResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&profileReadJSON{Header: p.Header, Profile: p.Profile})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a profile read response.
+func (p *ProfileReadResponse) UnmarshalJSON(b []byte) error {
+	j := &profileReadJSON{Header: header(p.Header), Profile: p.Profile}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if j.Profile == nil {
+		return errors.Wrap(ErrInvalidFrame, "no profile")
+	}
+	p.Header, p.Profile = j.Header, j.Profile
+	return nil
+}
+
+// logDataReadJSON is the JSON representation of LogDataReadResponse.
+type logDataReadJSON struct {
+	Header *FALinkHeader `json:"header"`
+	Log    *LogData      `json:"log,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the log data read response.
+func (l *LogDataReadResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&logDataReadJSON{Header: l.Header, Log: l.Log})
+}
+
+// UnmarshalJSON sets the values retrieved from the JSON encoding of a log data read response.
+func (l *LogDataReadResponse) UnmarshalJSON(b []byte) error {
+	j := &logDataReadJSON{Header: header(l.Header), Log: l.Log}
+	if err := json.Unmarshal(b, j); err != nil {
+		return err
+	}
+	if j.Log == nil {
+		return errors.Wrap(ErrInvalidFrame, "no log")
+	}
+	l.Header, l.Log = j.Header, j.Log
+	return nil
+}
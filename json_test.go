@@ -0,0 +1,175 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, v := range validFrames() {
+		j, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("%T: %v", v, err)
+		}
+
+		f, err := flnet.ParseJSON(j)
+		if err != nil {
+			t.Fatalf("%T: %v: %s", v, err, j)
+		}
+
+		want, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%T: got %x, want %x", f, got, want)
+		}
+	}
+}
+
+func TestMessageJSON(t *testing.T) {
+	m := flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0x1000, []byte{0x01, 0xab})
+	serialized := `{"header":{"h_type":"FACN","tfl":66,"sa":2,"da":1,"v_seq":0,"seq":1,` +
+		`"m_ctl":["PPT","RPL"],"uls":0,"m_sz":2,"m_add":4096,"mft":0,"m_rlt":0,"reserved":0,` +
+		`"tcd":"ByteBlockRead","ver":0,"c_ad1":0,"c_sz1":0,"c_ad2":0,"c_sz2":0,"mode":49,` +
+		`"p_type":128,"pri":0,"cbn":1,"tbn":1,"bsize":66,"lks":0,"tw":50,"rct":0},"data":"01ab"}`
+
+	t.Run("Marshal", func(t *testing.T) {
+		got, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(serialized, string(got)); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		got, err := flnet.ParseJSON([]byte(serialized))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(m, got); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("Fixture", func(t *testing.T) {
+		h := flnet.NewHeader()
+		fixture := `{"tcd":1000,"sa":3,"da":255,"m_ctl":["BCT","0x00000001"]}`
+		if err := json.Unmarshal([]byte(fixture), h); err != nil {
+			t.Fatal(err)
+		}
+		want := flnet.NewHeader(
+			flnet.WithTCD(1000),
+			flnet.WithSource(3),
+			flnet.WithDest(flnet.NodeBroadcast),
+			flnet.WithBroadcast(),
+		)
+		want.MCTL |= 1
+		if diff := cmp.Diff(want, h); diff != "" {
+			t.Error(diff)
+		}
+	})
+}
+
+func TestJSONNotPrintable(t *testing.T) {
+	tok := flnet.NewToken()
+	tok.Header.HType = [4]byte{0x8a, '0', '0', '0'}
+	frames := []struct {
+		frame flnet.FLnet
+		hex   string
+	}{
+		{tok, `{"hex":"8a303030"}`},
+		{flnet.NewTrigger(1, 255, 0, 0, "\xff\xfeAB", "VENDOR", "MANUF."), `{"hex":"fffe4142"}`},
+		{flnet.NewNetworkParameterReadResponse(2, 1, 0, 1, &flnet.NetworkParameter{NodeName: "\xff\xfeAB"}), `{"hex":"fffe4142"}`},
+	}
+
+	for _, f := range frames {
+		j, err := json.Marshal(f.frame)
+		if err != nil {
+			t.Fatalf("%T: %v", f.frame, err)
+		}
+		if !bytes.Contains(j, []byte(f.hex)) {
+			t.Errorf("%T: %s does not contain %s", f.frame, j, f.hex)
+		}
+
+		got, err := flnet.ParseJSON(j)
+		if err != nil {
+			t.Fatalf("%T: %v: %s", f.frame, err, j)
+		}
+		if diff := cmp.Diff(f.frame, got); diff != "" {
+			t.Errorf("%T: %s", f.frame, diff)
+		}
+	}
+}
+
+func TestParseJSONInvalid(t *testing.T) {
+	for _, s := range []string{
+		`{}`,
+		`{"header":{"tcd":"Unknown"}}`,
+		`{"header":{"m_ctl":["XYZ"]}}`,
+		`{"header":{"h_type":"FA"}}`,
+		`{"header":{"h_type":{"hex":"0g"}}}`,
+		`{"header":{"h_type":1}}`,
+		`{"header":{"tcd":"Cyclic"},"data":"0g"}`,
+		`{"header":{"tcd":"WordBlockRead"},"data":"010203"}`,
+		`{"header":{"tcd":"Trigger"},"node_name":"NODE NAME IS LONG"}`,
+		`{"header":{"tcd":"NetworkParameterRead","m_ctl":["RPL"]}}`,
+		`{"header":{"tcd":"ProfileRead","m_ctl":["RPL"]}}`,
+		`{"header":{"tcd":"LogDataRead","m_ctl":["RPL"]}}`,
+	} {
+		if _, err := flnet.ParseJSON([]byte(s)); !errors.Is(err, flnet.ErrInvalidFrame) {
+			t.Errorf("%s: got %v, want %v", s, err, flnet.ErrInvalidFrame)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	data := []byte{0x12, 0x34}
+	cases := []struct {
+		frame fmt.Stringer
+		want  string
+	}{
+		{
+			flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0x1000, []byte{0x01, 0xab}),
+			"TCD=ByteBlockRead SA=2 DA=1 M_CTL=PPT|RPL V_SEQ=0 SEQ=1 TFL=66 M_ADD=0x00001000 M_SZ=2 M_RLT=0 Data=01ab",
+		},
+		{
+			flnet.NewWordBlockWriteRequest(1, 2, 0, 1, 0x100, []uint16{1, 0xabcd}),
+			"TCD=WordBlockWrite SA=1 DA=2 M_CTL=PPT V_SEQ=0 SEQ=1 TFL=68 M_ADD=0x00000100 M_SZ=2 Data=[0001 abcd]",
+		},
+		{
+			flnet.NewCyclic(1, 255, 0, 0, 1, 0, 0, &data),
+			"TCD=Cyclic SA=1 DA=255 M_CTL=0 V_SEQ=0 SEQ=0 TFL=66 C_AD1=0x0000 C_SZ1=1 C_AD2=0x0000 C_SZ2=0 CBN=1 TBN=1 Data=1234",
+		},
+		{
+			flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."),
+			`TCD=Trigger SA=1 DA=255 M_CTL=0 V_SEQ=0 SEQ=0 TFL=96 NDN="NODE" VDN="VENDOR" MSN="MANUF."`,
+		},
+		{
+			flnet.NewTransparentMessage(1, 2, 0, 1, 1000, false, nil),
+			"TCD=Transparent(1000) SA=1 DA=2 M_CTL=PPT V_SEQ=0 SEQ=1 TFL=64 M_ADD=0x00000000 M_SZ=0",
+		},
+	}
+
+	for _, c := range cases {
+		if diff := cmp.Diff(c.want, c.frame.String()); diff != "" {
+			t.Errorf("%T: %s", c.frame, diff)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Socket indexes of LogData.SocketErrors.
+const (
+	SocketCyclic = iota
+	SocketMessage
+	SocketParticipation
+	SocketTrigger
+)
+
+// LogData is the log data of a node, which is carried by the log data read message.
+// Every counter is encoded as a 32-bit big endian value, and the rest of
+// the 512-byte log area is reserved.
+type LogData struct {
+	SendCount                      uint32    `json:"send_count"`
+	SendErrors                     uint32    `json:"send_errors"`
+	EthernetSendErrors             uint32    `json:"ethernet_send_errors"`
+	ReceiveCount                   uint32    `json:"receive_count"`
+	ReceiveErrors                  uint32    `json:"receive_errors"`
+	EthernetReceiveErrors          uint32    `json:"ethernet_receive_errors"`
+	SocketErrors                   [4]uint32 `json:"socket_errors"`
+	CyclicReceiveErrors            uint32    `json:"cyclic_receive_errors"`
+	CyclicAddressSizeErrors        uint32    `json:"cyclic_address_size_errors"`
+	CBNErrors                      uint32    `json:"cbn_errors"`
+	TBNErrors                      uint32    `json:"tbn_errors"`
+	BSizeErrors                    uint32    `json:"bsize_errors"`
+	MessageRetransmissions         uint32    `json:"message_retransmissions"`
+	MessageRetransmissionOverflows uint32    `json:"message_retransmission_overflows"`
+	MessageReceiveErrors           uint32    `json:"message_receive_errors"`
+	MessageSequenceErrors          uint32    `json:"message_sequence_errors"`
+	TokenMultipleRecognitions      uint32    `json:"token_multiple_recognitions"`
+	TokenDiscards                  uint32    `json:"token_discards"`
+	TokenRetransmissions           uint32    `json:"token_retransmissions"`
+	TokenHoldingTimeouts           uint32    `json:"token_holding_timeouts"`
+	TokenWatchdogTimeouts          uint32    `json:"token_watchdog_timeouts"`
+	FrameWaits                     uint32    `json:"frame_waits"`
+	ParticipationCount             uint32    `json:"participation_count"`
+	SelfLeaveCount                 uint32    `json:"self_leave_count"`
+	SkipLeaveCount                 uint32    `json:"skip_leave_count"`
+	OtherNodeLeaveCount            uint32    `json:"other_node_leave_count"`
+}
+
+// logCounters is the number of the counters in LogData.
+const logCounters = 29
+
+// counters returns the counters of LogData in the order on the wire.
+// It returns an array so that decoding does not allocate memory.
+func (l *LogData) counters() [logCounters]*uint32 {
+	return [logCounters]*uint32{
+		&l.SendCount,
+		&l.SendErrors,
+		&l.EthernetSendErrors,
+		&l.ReceiveCount,
+		&l.ReceiveErrors,
+		&l.EthernetReceiveErrors,
+		&l.SocketErrors[SocketCyclic],
+		&l.SocketErrors[SocketMessage],
+		&l.SocketErrors[SocketParticipation],
+		&l.SocketErrors[SocketTrigger],
+		&l.CyclicReceiveErrors,
+		&l.CyclicAddressSizeErrors,
+		&l.CBNErrors,
+		&l.TBNErrors,
+		&l.BSizeErrors,
+		&l.MessageRetransmissions,
+		&l.MessageRetransmissionOverflows,
+		&l.MessageReceiveErrors,
+		&l.MessageSequenceErrors,
+		&l.TokenMultipleRecognitions,
+		&l.TokenDiscards,
+		&l.TokenRetransmissions,
+		&l.TokenHoldingTimeouts,
+		&l.TokenWatchdogTimeouts,
+		&l.FrameWaits,
+		&l.ParticipationCount,
+		&l.SelfLeaveCount,
+		&l.SkipLeaveCount,
+		&l.OtherNodeLeaveCount,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a LogData.
+func (l *LogData) MarshalBinary() ([]byte, error) {
+	b := make([]byte, l.MarshalLen())
+	if err := l.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (l *LogData) MarshalTo(b []byte) error {
+	if len(b) < l.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	offset := 0
+	for _, c := range l.counters() {
+		binary.BigEndian.PutUint32(b[offset:], *c)
+		offset += 4
+	}
+	for ; offset < l.MarshalLen(); offset++ {
+		b[offset] = 0
+	}
+
+	return nil
+}
+
+// MarshalLen returns the serial length of LogData.
+func (l *LogData) MarshalLen() int {
+	return 512
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a LogData.
+func (l *LogData) UnmarshalBinary(b []byte) error {
+	if len(b) < l.MarshalLen() {
+		return errTooShort("log data", l.MarshalLen(), b)
+	}
+
+	offset := 0
+	for _, c := range l.counters() {
+		*c = binary.BigEndian.Uint32(b[offset : offset+4])
+		offset += 4
+	}
+
+	return nil
+}
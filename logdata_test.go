@@ -0,0 +1,69 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func newTestLogData() (*flnet.LogData, []byte) {
+	l := &flnet.LogData{
+		SendCount:            0x01020304,
+		ReceiveCount:         0x00000100,
+		SocketErrors:         [4]uint32{0, 1, 2, 3},
+		CyclicReceiveErrors:  5,
+		TokenRetransmissions: 6,
+		FrameWaits:           7,
+		ParticipationCount:   8,
+		OtherNodeLeaveCount:  9,
+	}
+
+	b := make([]byte, 512)
+	copy(b[0:], []byte{0x01, 0x02, 0x03, 0x04})  // SendCount
+	copy(b[12:], []byte{0x00, 0x00, 0x01, 0x00}) // ReceiveCount
+	b[31] = 1                                    // SocketErrors[SocketMessage]
+	b[35] = 2                                    // SocketErrors[SocketParticipation]
+	b[39] = 3                                    // SocketErrors[SocketTrigger]
+	b[43] = 5                                    // CyclicReceiveErrors
+	b[87] = 6                                    // TokenRetransmissions
+	b[99] = 7                                    // FrameWaits
+	b[103] = 8                                   // ParticipationCount
+	b[115] = 9                                   // OtherNodeLeaveCount
+
+	return l, b
+}
+
+func TestLogData(t *testing.T) {
+	want, serialized := newTestLogData()
+
+	t.Run("Decode", func(t *testing.T) {
+		got := &flnet.LogData{}
+		if err := got.UnmarshalBinary(serialized); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+	t.Run("Serialize", func(t *testing.T) {
+		got, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(serialized, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+	t.Run("Too short", func(t *testing.T) {
+		err := (&flnet.LogData{}).UnmarshalBinary(serialized[:511])
+		if got, want := err, flnet.ErrTooShortToParse; !errors.Is(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -5,12 +5,20 @@
 package flnet
 
 import (
+	"bytes"
 	"encoding/binary"
 	"io"
 
 	"github.com/pkg/errors"
 )
 
+// Result definitions of response messages, stored in M_RLT.
+const (
+	ResultNormal uint8 = iota
+	ResultNotSupported
+	ResultNotExecutable
+)
+
 // FLnet is an interface that defines FL-net messages.
 type FLnet interface {
 	MarshalBinary() ([]byte, error)
@@ -20,14 +28,117 @@ type FLnet interface {
 }
 
 // Parse decodes the given bytes.
-// This function checks the TCD.
+// This function checks the TCD, and the decoders registered by RegisterDecoder
+// take precedence over the built-in frame types.
+// The payload of the frame is copied, so b can be reused after Parse returns.
+// A frame shorter than BSIZE is reported as ErrTooShortToParse.
 func Parse(b []byte) (FLnet, error) {
+	t, rpl, err := peekFrame(b)
+	if err != nil {
+		return nil, err
+	}
+
+	f := registeredFrame(t)
+	if f == nil {
+		f = newFrame(t, rpl)
+	}
+
+	if err := unmarshalFrame(f, t, b, false); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// peekFrame returns the TCD and the RPL flag of the frame in b,
+// which choose the type to decode it as.
+func peekFrame(b []byte) (uint16, bool, error) {
 	if len(b) < 64 {
-		return nil, ErrTooShortToParse
+		return 0, false, errTooShort(headerField(len(b)), 64, b)
 	}
 
-	var f FLnet
 	t := binary.BigEndian.Uint16(b[40:42])
+	rpl := binary.BigEndian.Uint32(b[24:28])&mctlRPL != 0
+	return t, rpl, nil
+}
+
+// aliasUnmarshaler is implemented by the frames whose payload can refer to
+// the given bytes instead of a copy of them.
+type aliasUnmarshaler interface {
+	unmarshal(b []byte, alias bool) error
+}
+
+// unmarshalFrame decodes b into f, aliasing the payload if alias is true and
+// f supports it. The error is returned as a ParseError with the TCD.
+func unmarshalFrame(f FLnet, t uint16, b []byte, alias bool) error {
+	var err error
+	if a, ok := f.(aliasUnmarshaler); ok && alias {
+		err = a.unmarshal(b, true)
+	} else {
+		err = f.UnmarshalBinary(b)
+	}
+	if err == nil {
+		return nil
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		pe = &ParseError{Err: err}
+	}
+	pe.TCD = t
+	return pe
+}
+
+// payload returns the payload src stored in dst.
+// If alias is true, src itself is returned. Otherwise src is copied,
+// reusing the capacity of dst. An empty payload keeps dst being nil or not.
+func payload(dst, src []byte, alias bool) []byte {
+	if len(src) == 0 {
+		return dst[:0]
+	}
+	if alias {
+		return src
+	}
+	return append(dst[:0], src...)
+}
+
+// errTooShort returns a ParseError of ErrTooShortToParse at the end of b.
+// want is the length required to decode field.
+func errTooShort(field string, want int, b []byte) error {
+	return &ParseError{
+		Offset: len(b),
+		Field:  field,
+		Err:    errors.Wrapf(ErrTooShortToParse, "need %d bytes, got %d", want, len(b)),
+	}
+}
+
+// checkBSize returns an error if b is shorter than BSIZE of h,
+// as the data following the header is truncated.
+func checkBSize(h *FALinkHeader, b []byte) error {
+	if len(b) < int(h.BSize) {
+		return errTooShort("data", int(h.BSize), b)
+	}
+	return nil
+}
+
+// offsetError moves the offset of err by n if it is a ParseError,
+// so that the offset in the data is translated to the one in the frame.
+// Other errors are wrapped in a ParseError at n as the cause in field.
+func offsetError(err error, n int, field string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Offset += n
+		return pe
+	}
+	return &ParseError{
+		Offset: n,
+		Field:  field,
+		Err:    err,
+	}
+}
+
+// newFrame returns an empty frame of the built-in type for the TCD.
+func newFrame(t uint16, rpl bool) FLnet {
+	var f FLnet
 
 	switch t {
 	// Transfer Messages
@@ -41,6 +152,132 @@ func Parse(b []byte) (FLnet, error) {
 			Header: &FALinkHeader{},
 			Data:   d,
 		}
+	case TCDParticipationRequest:
+		f = &ParticipationRequest{
+			ParticipationHeader: &ParticipationHeader{
+				Header: &FALinkHeader{},
+			},
+		}
+	case TCDByteBlockReadRequest:
+		if rpl {
+			f = &ByteBlockReadResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &ByteBlockReadRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDByteBlockWriteRequest:
+		if rpl {
+			f = &ByteBlockWriteResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &ByteBlockWriteRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDWordBlockReadRequest:
+		if rpl {
+			f = &WordBlockReadResponse{
+				WordMessage: &WordMessage{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &WordBlockReadRequest{
+				WordMessage: &WordMessage{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDWordBlockWriteRequest:
+		if rpl {
+			f = &WordBlockWriteResponse{
+				WordMessage: &WordMessage{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &WordBlockWriteRequest{
+				WordMessage: &WordMessage{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDNetworkParameterReadRequest:
+		if rpl {
+			f = &NetworkParameterReadResponse{
+				NetworkParameterMessage: &NetworkParameterMessage{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &NetworkParameterReadRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDNetworkParameterWriteRequest:
+		if rpl {
+			f = &NetworkParameterWriteResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &NetworkParameterWriteRequest{
+				NetworkParameterMessage: &NetworkParameterMessage{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDStopCommandRequest:
+		if rpl {
+			f = &StopCommandResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &StopCommandRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDOperationCommandRequest:
+		if rpl {
+			f = &OperationCommandResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &OperationCommandRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDProfileReadRequest:
+		if rpl {
+			f = &ProfileReadResponse{
+				Header: &FALinkHeader{},
+			}
+		} else {
+			f = &ProfileReadRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDLogDataReadRequest:
+		if rpl {
+			f = &LogDataReadResponse{
+				Header: &FALinkHeader{},
+			}
+		} else {
+			f = &LogDataReadRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDLogDataClearRequest:
+		if rpl {
+			f = &LogDataClearResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &LogDataClearRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
+	case TCDMessageReturnRequest:
+		if rpl {
+			f = &MessageReturnResponse{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		} else {
+			f = &MessageReturnRequest{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+		}
 	case TCDTrigger:
 		f = &Trigger{
 			ParticipationHeader: &ParticipationHeader{
@@ -48,50 +285,69 @@ func Parse(b []byte) (FLnet, error) {
 			},
 		}
 	default:
-		// If the combination of class and type is unknown or not supported, *Generic is used.
-		return nil, ErrNotImplemented
+		if t <= TCDTransparentMax {
+			f = &TransparentMessage{
+				Message: &Message{Header: &FALinkHeader{}},
+			}
+			break
+		}
+		// If the TCD is unknown or not supported, *Generic is used.
+		f = &Generic{
+			Message: &Message{Header: &FALinkHeader{}},
+		}
 	}
 
-	if err := f.UnmarshalBinary(b); err != nil {
-		return nil, errors.Wrap(err, "failed to decode FLnet")
-	}
-	return f, nil
+	return f
+}
+
+// Generic is a frame of FA Link frame whose TCD is unknown or not supported.
+// Data holds the raw bytes following the header.
+type Generic struct {
+	*Message
 }
 
 // Token is a token frame of FA Link frame.
+// The token holder may send its cyclic data in Data with the token,
+// whose areas are described by C_AD1/C_SZ1 and C_AD2/C_SZ2 as in Cyclic.
 type Token struct {
 	Header *FALinkHeader
+	Data   []byte
 }
 
 // NewToken creates a new Token.
 func NewToken() *Token {
 	t := &Token{
-		Header: NewFALinkHeader(
-			[4]byte{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
-			0x40,                            // TFL
-			0x1,                             // SNA
-			0x55,                            // DNA
-			0,                               // V_SEQ
-			0,                               // SEQ
-			false, false, false,             // M_CTL
-			0, 0, // ULS, M_SZ
-			0,       // M_ADD
-			0, 0, 0, // MFT, M_RLT, reserved
-			TCDToken, 0, // TCD, VER
-			0, 0, // C_AD1, C_SZ1
-			0, 0, // C_AD2, C_SZ2
-			0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
-			1, 1, 0x40, // CBN, TBN, BSIZE
-			0, 0x32, 0, // LKS, TW, RCT
+		Header: NewHeader(
+			WithSource(0x1),
+			WithDest(0x55),
+			WithTCD(TCDToken),
 		),
 	}
 	return t
 }
 
+// NewTokenWithCyclic creates a new Token which carries the cyclic data of the sender.
+// dna is the node to which the token is passed.
+func NewTokenWithCyclic(sna, dna uint8, vseq uint32, cad1, csz1, cad2, csz2 uint16, data []byte) *Token {
+	t := &Token{
+		Header: NewHeader(
+			WithSource(sna),
+			WithDest(dna),
+			WithVSeq(vseq),
+			WithTCD(TCDToken),
+			WithArea1(cad1, csz1),
+			WithArea2(cad2, csz2),
+			WithPayloadLen(len(data)),
+		),
+		Data: data,
+	}
+	return t
+}
+
 // MarshalBinary returns the byte sequence generated from a Token.
 func (t *Token) MarshalBinary() ([]byte, error) {
-	b, err := t.Header.MarshalBinary()
-	if err != nil {
+	b := make([]byte, t.MarshalLen())
+	if err := t.MarshalTo(b); err != nil {
 		return nil, err
 	}
 	return b, nil
@@ -99,27 +355,46 @@ func (t *Token) MarshalBinary() ([]byte, error) {
 
 //MarshalTo puts the byte sequence in the byte array given as b.
 func (t *Token) MarshalTo(b []byte) error {
+	if len(b) < t.MarshalLen() {
+		return ErrTooShortToMarshalBinary
+	}
+
 	if err := t.Header.MarshalTo(b); err != nil {
 		return err
 	}
+	copy(b[t.Header.MarshalLen():], t.Data)
 
 	return nil
 }
 
 // MarshalLen returns the serial length of Token.
 func (t *Token) MarshalLen() int {
-	return t.Header.MarshalLen()
+	return t.Header.MarshalLen() + len(t.Data)
 }
 
-// UnmarshalBinary sets the values retrieved from byte sequence in a FL-net common header.
+// UnmarshalBinary sets the values retrieved from byte sequence in a token frame.
+// The bytes following the header are copied to Data as the cyclic data.
 func (t *Token) UnmarshalBinary(b []byte) error {
+	return t.unmarshal(b, false)
+}
+
+func (t *Token) unmarshal(b []byte, alias bool) error {
 	err := t.Header.UnmarshalBinary(b)
 	if err != nil {
 		return err
 	}
+	if err := checkBSize(t.Header, b); err != nil {
+		return err
+	}
+	t.Data = payload(t.Data, b[t.Header.MarshalLen():], alias)
+
 	return nil
 }
 
+// participationLen is the length of NDN, VDN, MSN and the reserve
+// following the header of ParticipationHeader.
+const participationLen = 32
+
 // ParticipationHeader is used for the commands of token participation.
 type ParticipationHeader struct {
 	Header  *FALinkHeader
@@ -174,6 +449,9 @@ func (p *ParticipationHeader) UnmarshalBinary(b []byte) error {
 	if err != nil {
 		return err
 	}
+	if len(b) < p.MarshalLen() {
+		return errTooShort(participationField(len(b)), p.MarshalLen(), b)
+	}
 
 	offset := p.Header.MarshalLen()
 	copy(p.NDN[:], b[offset:offset+len(p.NDN)])
@@ -184,7 +462,23 @@ func (p *ParticipationHeader) UnmarshalBinary(b []byte) error {
 
 	copy(p.MSN[:], b[offset:offset+len(p.MSN)])
 
-	return nil
+	return checkBSize(p.Header, b)
+}
+
+// participationField returns the name of the field of ParticipationHeader at offset.
+func participationField(offset int) string {
+	switch {
+	case offset < 64:
+		return headerField(offset)
+	case offset < 74:
+		return "NDN"
+	case offset < 84:
+		return "VDN"
+	case offset < 94:
+		return "MSN"
+	default:
+		return "Reserve"
+	}
 }
 
 // Trigger is a trigger frame of FA Link frame.
@@ -196,26 +490,17 @@ type Trigger struct {
 func NewTrigger(sna, dna uint8, vseq, seq uint32, ndn, vdn, msn string) *Trigger {
 	t := &Trigger{}
 	t.ParticipationHeader = &ParticipationHeader{}
-	t.ParticipationHeader.Header = NewFALinkHeader(
-		[4]uint8{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
-		0,                                // TFL
-		sna,                              // SNA
-		dna,                              // DNA
-		vseq,                             // V_SEQ
-		seq,                              // SEQ
-		false, false, false,              // M_CTL
-		0, 0, // ULS, M_SZ
-		0,          // M_ADD
-		0x0a, 0, 0, // MFT, M_RLT, reserved
-		TCDTrigger, 0, // TCD, VER
-		0, 0x04, // C_AD1, C_SZ1
-		0, 0x40, // C_AD2, C_SZ2
-		0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
-		1, 1, 0x40, // CBN, TBN, BSIZE
-		0, 0x32, 0, // LKS, TW, RCT
+	t.ParticipationHeader.Header = NewHeader(
+		WithSource(sna),
+		WithDest(dna),
+		WithVSeq(vseq),
+		WithSeq(seq),
+		WithMFT(0x0a),
+		WithTCD(TCDTrigger),
+		WithArea1(0, 0x04),
+		WithArea2(0, 0x40),
+		WithPayloadLen(participationLen),
 	)
-	t.ParticipationHeader.Header.TFL = uint32(t.MarshalLen())
-	t.ParticipationHeader.Header.BSize = uint16(t.MarshalLen())
 
 	t.ParticipationHeader.NDN = [10]byte{0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20}
 	t.ParticipationHeader.VDN = [10]byte{0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20}
@@ -276,26 +561,17 @@ type ParticipationRequest struct {
 func NewParticipationRequest(sna, dna uint8, vseq, seq uint32, ndn, vdn, msn string) *ParticipationRequest {
 	p := &ParticipationRequest{}
 	p.ParticipationHeader = &ParticipationHeader{}
-	p.ParticipationHeader.Header = NewFALinkHeader(
-		[4]uint8{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
-		0,                                // TFL
-		sna,                              // SNA
-		dna,                              // DNA
-		vseq,                             // V_SEQ
-		seq,                              // SEQ
-		false, false, false,              // M_CTL
-		0, 0, // ULS, M_SZ
-		0,          // M_ADD
-		0x0a, 0, 0, // MFT, M_RLT, reserved
-		TCDParticipationRequest, 0, // TCD, VER
-		0, 0x04, // C_AD1, C_SZ1
-		0, 0x40, // C_AD2, C_SZ2
-		0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
-		1, 1, 0x40, // CBN, TBN, BSIZE
-		0, 0x32, 0, // LKS, TW, RCT
+	p.ParticipationHeader.Header = NewHeader(
+		WithSource(sna),
+		WithDest(dna),
+		WithVSeq(vseq),
+		WithSeq(seq),
+		WithMFT(0x0a),
+		WithTCD(TCDParticipationRequest),
+		WithArea1(0, 0x04),
+		WithArea2(0, 0x40),
+		WithPayloadLen(participationLen),
 	)
-	p.ParticipationHeader.Header.TFL = uint32(p.MarshalLen())
-	p.ParticipationHeader.Header.BSize = uint16(p.MarshalLen())
 
 	p.ParticipationHeader.NDN = [10]byte{0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20}
 	p.ParticipationHeader.VDN = [10]byte{0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20}
@@ -355,39 +631,26 @@ type Cyclic struct {
 
 // NewCyclic creates a new Cyclic.
 func NewCyclic(sna, dna uint8, vseq uint32, cad1, csz1, cad2, csz2 uint16, data *[]byte) *Cyclic {
-	c := &Cyclic{
-		Header: NewFALinkHeader(
-			[4]byte{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
-			0x40,                            // TFL
-			sna,                             // SNA
-			dna,                             // DNA
-			vseq,                            // V_SEQ
-			0,                               // SEQ
-			false, false, false,             // M_CTL
-			0, 0, // ULS, M_SZ
-			0,          // M_ADD
-			0x0a, 0, 0, // MFT, M_RLT, reserved
-			TCDCyclic, 0, // TCD, VER
-			cad1, csz1, // C_AD1, C_SZ1
-			cad2, csz2, // C_AD2, C_SZ2
-			0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
-			1, 1, 0x40, // CBN, TBN, BSIZE
-			0, 0x32, 0, // LKS, TW, RCT
-		),
+	opts := []HeaderOption{
+		WithSource(sna),
+		WithDest(dna),
+		WithVSeq(vseq),
+		WithMFT(0x0a),
+		WithTCD(TCDCyclic),
+		WithArea1(cad1, csz1),
+		WithArea2(cad2, csz2),
 	}
 
+	c := &Cyclic{}
 	if data != nil {
 		c.Data = *data
-		lenFrame := c.MarshalLen()
-		c.Header.TFL = uint32(lenFrame)
-		c.Header.BSize = uint16(lenFrame)
+		opts = append(opts, WithPayloadLen(len(c.Data)))
 	} else {
-		noData := []byte{}
-		c.Data = noData
-		lenFrame := c.MarshalLen()
-		c.Header.TFL = uint32(lenFrame) + uint32(c.Header.CSZ1+c.Header.CSZ2)*2
-		c.Header.BSize = uint16(lenFrame)
+		c.Data = []byte{}
+		opts = append(opts, WithPayloadLen(0), WithTotalLen(int(csz1+csz2)*2))
 	}
+	c.Header = NewHeader(opts...)
+
 	return c
 }
 
@@ -417,12 +680,717 @@ func (c *Cyclic) MarshalLen() int {
 }
 
 // UnmarshalBinary sets the values retrieved from byte sequence in a cyclic frame.
+// The cyclic data is copied to Data, reusing its capacity.
 func (c *Cyclic) UnmarshalBinary(b []byte) error {
+	return c.unmarshal(b, false)
+}
+
+func (c *Cyclic) unmarshal(b []byte, alias bool) error {
 	err := c.Header.UnmarshalBinary(b)
 	if err != nil {
 		return err
 	}
-	c.Data = b[c.Header.MarshalLen():]
+	if err := checkBSize(c.Header, b); err != nil {
+		return err
+	}
+	c.Data = payload(c.Data, b[c.Header.MarshalLen():], alias)
+
+	return nil
+}
+
+// Message is the common part of the message transmission frames,
+// which consists of a FA Link header followed by the message data.
+type Message struct {
+	Header *FALinkHeader
+	Data   []byte
+}
+
+// newMessage creates a new Message.
+func newMessage(sna, dna uint8, vseq, seq uint32, tcd uint16, rpl bool, madd uint32, msz uint16, data []byte) *Message {
+	return &Message{
+		Header: newMessageHeader(sna, dna, vseq, seq, tcd, rpl, madd, msz, len(data)),
+		Data:   data,
+	}
+}
+
+// newMessageHeader creates a new header of the message transmission frames
+// followed by n bytes of payload.
+// BCT is set when dna is the broadcast node, otherwise PPT is set.
+func newMessageHeader(sna, dna uint8, vseq, seq uint32, tcd uint16, rpl bool, madd uint32, msz uint16, n int) *FALinkHeader {
+	opts := []HeaderOption{
+		WithSource(sna),
+		WithDest(dna),
+		WithVSeq(vseq),
+		WithSeq(seq),
+		WithTCD(tcd),
+		WithMessage(madd, msz),
+		WithPayloadLen(n),
+	}
+	if dna == NodeBroadcast {
+		opts = append(opts, WithBroadcast())
+	} else {
+		opts = append(opts, WithPointToPoint())
+	}
+	if rpl {
+		opts = append(opts, WithResponse())
+	}
+
+	return NewHeader(opts...)
+}
+
+// MarshalBinary returns the byte sequence generated from a Message.
+func (m *Message) MarshalBinary() ([]byte, error) {
+	b := make([]byte, m.MarshalLen())
+	if err := m.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (m *Message) MarshalTo(b []byte) error {
+	l := len(b)
+	if l < m.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	if err := m.Header.MarshalTo(b); err != nil {
+		return err
+	}
+	copy(b[m.Header.MarshalLen():], m.Data)
+
+	return nil
+}
+
+// MarshalLen returns the serial length of Message.
+func (m *Message) MarshalLen() int {
+	return m.Header.MarshalLen() + len(m.Data)
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a message frame.
+// The message data is copied to Data, reusing its capacity.
+func (m *Message) UnmarshalBinary(b []byte) error {
+	return m.unmarshal(b, false)
+}
+
+func (m *Message) unmarshal(b []byte, alias bool) error {
+	err := m.Header.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+	if err := checkBSize(m.Header, b); err != nil {
+		return err
+	}
+	m.Data = payload(m.Data, b[m.Header.MarshalLen():], alias)
+
+	return nil
+}
+
+// ByteBlockReadRequest is a byte block read request frame of FA Link frame.
+// M_ADD holds the virtual address and M_SZ holds the size in bytes to read.
+type ByteBlockReadRequest struct {
+	*Message
+}
+
+// NewByteBlockReadRequest creates a new ByteBlockReadRequest.
+func NewByteBlockReadRequest(sna, dna uint8, vseq, seq uint32, madd uint32, msz uint16) *ByteBlockReadRequest {
+	return &ByteBlockReadRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDByteBlockReadRequest, false, madd, msz, nil),
+	}
+}
+
+// ByteBlockReadResponse is a byte block read response frame of FA Link frame.
+// Data holds the bytes read from the virtual address in M_ADD.
+type ByteBlockReadResponse struct {
+	*Message
+}
+
+// NewByteBlockReadResponse creates a new ByteBlockReadResponse.
+func NewByteBlockReadResponse(sna, dna uint8, vseq, seq uint32, madd uint32, data []byte) *ByteBlockReadResponse {
+	return &ByteBlockReadResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDByteBlockReadRequest, true, madd, uint16(len(data)), data),
+	}
+}
+
+// ByteBlockWriteRequest is a byte block write request frame of FA Link frame.
+// Data holds the bytes to be written to the virtual address in M_ADD.
+type ByteBlockWriteRequest struct {
+	*Message
+}
+
+// NewByteBlockWriteRequest creates a new ByteBlockWriteRequest.
+func NewByteBlockWriteRequest(sna, dna uint8, vseq, seq uint32, madd uint32, data []byte) *ByteBlockWriteRequest {
+	return &ByteBlockWriteRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDByteBlockWriteRequest, false, madd, uint16(len(data)), data),
+	}
+}
+
+// ByteBlockWriteResponse is a byte block write response frame of FA Link frame.
+type ByteBlockWriteResponse struct {
+	*Message
+}
+
+// NewByteBlockWriteResponse creates a new ByteBlockWriteResponse.
+func NewByteBlockWriteResponse(sna, dna uint8, vseq, seq uint32, madd uint32, msz uint16) *ByteBlockWriteResponse {
+	return &ByteBlockWriteResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDByteBlockWriteRequest, true, madd, msz, nil),
+	}
+}
+
+// WordMessage is a message frame whose data is a sequence of 16-bit words.
+// The words are encoded in big endian.
+type WordMessage struct {
+	Header *FALinkHeader
+	Data   []uint16
+}
+
+// newWordMessage creates a new WordMessage.
+func newWordMessage(sna, dna uint8, vseq, seq uint32, tcd uint16, rpl bool, madd uint32, msz uint16, data []uint16) *WordMessage {
+	return &WordMessage{
+		Header: newMessageHeader(sna, dna, vseq, seq, tcd, rpl, madd, msz, len(data)*2),
+		Data:   data,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a WordMessage.
+func (w *WordMessage) MarshalBinary() ([]byte, error) {
+	b := make([]byte, w.MarshalLen())
+	if err := w.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (w *WordMessage) MarshalTo(b []byte) error {
+	l := len(b)
+	if l < w.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	if err := w.Header.MarshalTo(b); err != nil {
+		return err
+	}
+
+	offset := w.Header.MarshalLen()
+	for _, v := range w.Data {
+		binary.BigEndian.PutUint16(b[offset:], v)
+		offset += 2
+	}
+
+	return nil
+}
+
+// MarshalLen returns the serial length of WordMessage.
+func (w *WordMessage) MarshalLen() int {
+	return w.Header.MarshalLen() + len(w.Data)*2
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a word message frame.
+func (w *WordMessage) UnmarshalBinary(b []byte) error {
+	err := w.Header.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+
+	offset := w.Header.MarshalLen()
+	if n := len(b) - offset; n%2 != 0 {
+		return &ParseError{
+			Offset: len(b) - 1,
+			Field:  "data",
+			Err:    errors.Wrapf(ErrInvalidFrame, "%d bytes of data is not word aligned", n),
+		}
+	}
+	if err := checkBSize(w.Header, b); err != nil {
+		return err
+	}
+
+	w.Data = w.Data[:0]
+	for ; offset < len(b); offset += 2 {
+		w.Data = append(w.Data, binary.BigEndian.Uint16(b[offset:offset+2]))
+	}
 
 	return nil
 }
+
+// WordBlockReadRequest is a word block read request frame of FA Link frame.
+// M_ADD holds the word address and M_SZ holds the number of words to read.
+type WordBlockReadRequest struct {
+	*WordMessage
+}
+
+// NewWordBlockReadRequest creates a new WordBlockReadRequest.
+func NewWordBlockReadRequest(sna, dna uint8, vseq, seq uint32, madd uint32, msz uint16) *WordBlockReadRequest {
+	return &WordBlockReadRequest{
+		WordMessage: newWordMessage(sna, dna, vseq, seq, TCDWordBlockReadRequest, false, madd, msz, nil),
+	}
+}
+
+// WordBlockReadResponse is a word block read response frame of FA Link frame.
+// Data holds the words read from the word address in M_ADD.
+type WordBlockReadResponse struct {
+	*WordMessage
+}
+
+// NewWordBlockReadResponse creates a new WordBlockReadResponse.
+func NewWordBlockReadResponse(sna, dna uint8, vseq, seq uint32, madd uint32, data []uint16) *WordBlockReadResponse {
+	return &WordBlockReadResponse{
+		WordMessage: newWordMessage(sna, dna, vseq, seq, TCDWordBlockReadRequest, true, madd, uint16(len(data)), data),
+	}
+}
+
+// WordBlockWriteRequest is a word block write request frame of FA Link frame.
+// Data holds the words to be written to the word address in M_ADD.
+type WordBlockWriteRequest struct {
+	*WordMessage
+}
+
+// NewWordBlockWriteRequest creates a new WordBlockWriteRequest.
+func NewWordBlockWriteRequest(sna, dna uint8, vseq, seq uint32, madd uint32, data []uint16) *WordBlockWriteRequest {
+	return &WordBlockWriteRequest{
+		WordMessage: newWordMessage(sna, dna, vseq, seq, TCDWordBlockWriteRequest, false, madd, uint16(len(data)), data),
+	}
+}
+
+// WordBlockWriteResponse is a word block write response frame of FA Link frame.
+type WordBlockWriteResponse struct {
+	*WordMessage
+}
+
+// NewWordBlockWriteResponse creates a new WordBlockWriteResponse.
+func NewWordBlockWriteResponse(sna, dna uint8, vseq, seq uint32, madd uint32, msz uint16) *WordBlockWriteResponse {
+	return &WordBlockWriteResponse{
+		WordMessage: newWordMessage(sna, dna, vseq, seq, TCDWordBlockWriteRequest, true, madd, msz, nil),
+	}
+}
+
+// NetworkParameterMessage is a message frame whose data is a NetworkParameter.
+type NetworkParameterMessage struct {
+	Header    *FALinkHeader
+	Parameter *NetworkParameter
+}
+
+// newNetworkParameterMessage creates a new NetworkParameterMessage.
+// A nil param is replaced with an empty NetworkParameter.
+func newNetworkParameterMessage(sna, dna uint8, vseq, seq uint32, tcd uint16, rpl bool, param *NetworkParameter) *NetworkParameterMessage {
+	if param == nil {
+		param = &NetworkParameter{}
+	}
+	l := param.MarshalLen()
+	return &NetworkParameterMessage{
+		Header:    newMessageHeader(sna, dna, vseq, seq, tcd, rpl, 0, uint16(l), l),
+		Parameter: param,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a NetworkParameterMessage.
+func (n *NetworkParameterMessage) MarshalBinary() ([]byte, error) {
+	b := make([]byte, n.MarshalLen())
+	if err := n.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (n *NetworkParameterMessage) MarshalTo(b []byte) error {
+	l := len(b)
+	if l < n.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	if err := n.Header.MarshalTo(b); err != nil {
+		return err
+	}
+
+	return n.Parameter.MarshalTo(b[n.Header.MarshalLen():])
+}
+
+// MarshalLen returns the serial length of NetworkParameterMessage.
+func (n *NetworkParameterMessage) MarshalLen() int {
+	return n.Header.MarshalLen() + n.Parameter.MarshalLen()
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a network parameter message frame.
+// The parameter is decoded into Parameter if it is not nil.
+func (n *NetworkParameterMessage) UnmarshalBinary(b []byte) error {
+	err := n.Header.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+
+	if n.Parameter == nil {
+		n.Parameter = &NetworkParameter{}
+	}
+	if err := n.Parameter.UnmarshalBinary(b[n.Header.MarshalLen():]); err != nil {
+		return offsetError(err, n.Header.MarshalLen(), "network parameter")
+	}
+
+	return checkBSize(n.Header, b)
+}
+
+// NetworkParameterReadRequest is a network parameter read request frame of FA Link frame.
+type NetworkParameterReadRequest struct {
+	*Message
+}
+
+// NewNetworkParameterReadRequest creates a new NetworkParameterReadRequest.
+func NewNetworkParameterReadRequest(sna, dna uint8, vseq, seq uint32) *NetworkParameterReadRequest {
+	return &NetworkParameterReadRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDNetworkParameterReadRequest, false, 0, 0, nil),
+	}
+}
+
+// NetworkParameterReadResponse is a network parameter read response frame of FA Link frame.
+type NetworkParameterReadResponse struct {
+	*NetworkParameterMessage
+}
+
+// NewNetworkParameterReadResponse creates a new NetworkParameterReadResponse.
+// If param is nil, an empty NetworkParameter is sent.
+func NewNetworkParameterReadResponse(sna, dna uint8, vseq, seq uint32, param *NetworkParameter) *NetworkParameterReadResponse {
+	return &NetworkParameterReadResponse{
+		NetworkParameterMessage: newNetworkParameterMessage(sna, dna, vseq, seq, TCDNetworkParameterReadRequest, true, param),
+	}
+}
+
+// NetworkParameterWriteRequest is a network parameter write request frame of FA Link frame.
+// It changes the node name and the common memory assignment of the destination node.
+type NetworkParameterWriteRequest struct {
+	*NetworkParameterMessage
+}
+
+// NewNetworkParameterWriteRequest creates a new NetworkParameterWriteRequest.
+// If param is nil, an empty NetworkParameter is sent.
+func NewNetworkParameterWriteRequest(sna, dna uint8, vseq, seq uint32, param *NetworkParameter) *NetworkParameterWriteRequest {
+	return &NetworkParameterWriteRequest{
+		NetworkParameterMessage: newNetworkParameterMessage(sna, dna, vseq, seq, TCDNetworkParameterWriteRequest, false, param),
+	}
+}
+
+// NetworkParameterWriteResponse is a network parameter write response frame of FA Link frame.
+type NetworkParameterWriteResponse struct {
+	*Message
+}
+
+// NewNetworkParameterWriteResponse creates a new NetworkParameterWriteResponse.
+func NewNetworkParameterWriteResponse(sna, dna uint8, vseq, seq uint32) *NetworkParameterWriteResponse {
+	return &NetworkParameterWriteResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDNetworkParameterWriteRequest, true, 0, 0, nil),
+	}
+}
+
+// resultError returns the error corresponding to the result code mrlt.
+func resultError(mrlt uint8) error {
+	switch mrlt {
+	case ResultNormal:
+		return nil
+	case ResultNotSupported:
+		return ErrNotSupported
+	case ResultNotExecutable:
+		return ErrNotExecutable
+	default:
+		return errors.Wrapf(ErrUnknownResult, "M_RLT=%d", mrlt)
+	}
+}
+
+// StopCommandRequest is a stop command request frame of FA Link frame.
+// It puts the upper layer of the destination node into STOP.
+type StopCommandRequest struct {
+	*Message
+}
+
+// NewStopCommandRequest creates a new StopCommandRequest.
+func NewStopCommandRequest(sna, dna uint8, vseq, seq uint32) *StopCommandRequest {
+	return &StopCommandRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDStopCommandRequest, false, 0, 0, nil),
+	}
+}
+
+// StopCommandResponse is a stop command response frame of FA Link frame.
+type StopCommandResponse struct {
+	*Message
+}
+
+// NewStopCommandResponse creates a new StopCommandResponse with the result code mrlt.
+func NewStopCommandResponse(sna, dna uint8, vseq, seq uint32, mrlt uint8) *StopCommandResponse {
+	s := &StopCommandResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDStopCommandRequest, true, 0, 0, nil),
+	}
+	s.Header.MRLT = mrlt
+
+	return s
+}
+
+// Err returns the error corresponding to M_RLT, or nil on the normal result.
+func (s *StopCommandResponse) Err() error {
+	return resultError(s.Header.MRLT)
+}
+
+// OperationCommandRequest is an operation command request frame of FA Link frame.
+// It puts the upper layer of the destination node into RUN.
+type OperationCommandRequest struct {
+	*Message
+}
+
+// NewOperationCommandRequest creates a new OperationCommandRequest.
+func NewOperationCommandRequest(sna, dna uint8, vseq, seq uint32) *OperationCommandRequest {
+	return &OperationCommandRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDOperationCommandRequest, false, 0, 0, nil),
+	}
+}
+
+// OperationCommandResponse is an operation command response frame of FA Link frame.
+type OperationCommandResponse struct {
+	*Message
+}
+
+// NewOperationCommandResponse creates a new OperationCommandResponse with the result code mrlt.
+func NewOperationCommandResponse(sna, dna uint8, vseq, seq uint32, mrlt uint8) *OperationCommandResponse {
+	o := &OperationCommandResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDOperationCommandRequest, true, 0, 0, nil),
+	}
+	o.Header.MRLT = mrlt
+
+	return o
+}
+
+// Err returns the error corresponding to M_RLT, or nil on the normal result.
+func (o *OperationCommandResponse) Err() error {
+	return resultError(o.Header.MRLT)
+}
+
+// ProfileReadRequest is a profile read request frame of FA Link frame.
+type ProfileReadRequest struct {
+	*Message
+}
+
+// NewProfileReadRequest creates a new ProfileReadRequest.
+func NewProfileReadRequest(sna, dna uint8, vseq, seq uint32) *ProfileReadRequest {
+	return &ProfileReadRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDProfileReadRequest, false, 0, 0, nil),
+	}
+}
+
+// ProfileReadResponse is a profile read response frame of FA Link frame.
+// The system parameters of the device profile are carried in ASN.1 BER.
+type ProfileReadResponse struct {
+	Header  *FALinkHeader
+	Profile *DeviceProfile
+}
+
+// NewProfileReadResponse creates a new ProfileReadResponse.
+// If profile is nil, an empty DeviceProfile is sent. It returns an error
+// wrapping ErrInvalidProfile if profile cannot be encoded.
+func NewProfileReadResponse(sna, dna uint8, vseq, seq uint32, profile *DeviceProfile) (*ProfileReadResponse, error) {
+	if profile == nil {
+		profile = &DeviceProfile{}
+	}
+	b, err := profile.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProfileReadResponse{
+		Header:  newMessageHeader(sna, dna, vseq, seq, TCDProfileReadRequest, true, 0, uint16(len(b)), len(b)),
+		Profile: profile,
+	}, nil
+}
+
+// MarshalBinary returns the byte sequence generated from a ProfileReadResponse.
+func (p *ProfileReadResponse) MarshalBinary() ([]byte, error) {
+	b := make([]byte, p.MarshalLen())
+	if err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (p *ProfileReadResponse) MarshalTo(b []byte) error {
+	l := len(b)
+	if l < p.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	if err := p.Header.MarshalTo(b); err != nil {
+		return err
+	}
+
+	return p.Profile.MarshalTo(b[p.Header.MarshalLen():])
+}
+
+// MarshalLen returns the serial length of ProfileReadResponse.
+func (p *ProfileReadResponse) MarshalLen() int {
+	return p.Header.MarshalLen() + p.Profile.MarshalLen()
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a profile read response frame.
+// The profile is decoded into Profile if it is not nil.
+func (p *ProfileReadResponse) UnmarshalBinary(b []byte) error {
+	err := p.Header.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+
+	if p.Profile == nil {
+		p.Profile = &DeviceProfile{}
+	}
+	if err := p.Profile.UnmarshalBinary(b[p.Header.MarshalLen():]); err != nil {
+		return offsetError(err, p.Header.MarshalLen(), "device profile")
+	}
+
+	return checkBSize(p.Header, b)
+}
+
+// TransparentMessage is a transparent message frame of FA Link frame.
+// TCD is defined by the user application in the range up to TCDTransparentMax,
+// and Data is passed to the upper layer as it is.
+type TransparentMessage struct {
+	*Message
+}
+
+// NewTransparentMessage creates a new TransparentMessage.
+// tcd must not be larger than TCDTransparentMax.
+func NewTransparentMessage(sna, dna uint8, vseq, seq uint32, tcd uint16, rpl bool, data []byte) *TransparentMessage {
+	return &TransparentMessage{
+		Message: newMessage(sna, dna, vseq, seq, tcd, rpl, 0, uint16(len(data)), data),
+	}
+}
+
+// LogDataReadRequest is a log data read request frame of FA Link frame.
+type LogDataReadRequest struct {
+	*Message
+}
+
+// NewLogDataReadRequest creates a new LogDataReadRequest.
+func NewLogDataReadRequest(sna, dna uint8, vseq, seq uint32) *LogDataReadRequest {
+	return &LogDataReadRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDLogDataReadRequest, false, 0, 0, nil),
+	}
+}
+
+// LogDataReadResponse is a log data read response frame of FA Link frame.
+type LogDataReadResponse struct {
+	Header *FALinkHeader
+	Log    *LogData
+}
+
+// NewLogDataReadResponse creates a new LogDataReadResponse.
+// If log is nil, an empty LogData is sent.
+func NewLogDataReadResponse(sna, dna uint8, vseq, seq uint32, log *LogData) *LogDataReadResponse {
+	if log == nil {
+		log = &LogData{}
+	}
+	l := log.MarshalLen()
+	return &LogDataReadResponse{
+		Header: newMessageHeader(sna, dna, vseq, seq, TCDLogDataReadRequest, true, 0, uint16(l), l),
+		Log:    log,
+	}
+}
+
+// MarshalBinary returns the byte sequence generated from a LogDataReadResponse.
+func (l *LogDataReadResponse) MarshalBinary() ([]byte, error) {
+	b := make([]byte, l.MarshalLen())
+	if err := l.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (l *LogDataReadResponse) MarshalTo(b []byte) error {
+	if len(b) < l.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	if err := l.Header.MarshalTo(b); err != nil {
+		return err
+	}
+
+	return l.Log.MarshalTo(b[l.Header.MarshalLen():])
+}
+
+// MarshalLen returns the serial length of LogDataReadResponse.
+func (l *LogDataReadResponse) MarshalLen() int {
+	return l.Header.MarshalLen() + l.Log.MarshalLen()
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a log data read response frame.
+// The log data is decoded into Log if it is not nil.
+func (l *LogDataReadResponse) UnmarshalBinary(b []byte) error {
+	err := l.Header.UnmarshalBinary(b)
+	if err != nil {
+		return err
+	}
+
+	if l.Log == nil {
+		l.Log = &LogData{}
+	}
+	if err := l.Log.UnmarshalBinary(b[l.Header.MarshalLen():]); err != nil {
+		return offsetError(err, l.Header.MarshalLen(), "log data")
+	}
+
+	return checkBSize(l.Header, b)
+}
+
+// LogDataClearRequest is a log data clear request frame of FA Link frame.
+type LogDataClearRequest struct {
+	*Message
+}
+
+// NewLogDataClearRequest creates a new LogDataClearRequest.
+func NewLogDataClearRequest(sna, dna uint8, vseq, seq uint32) *LogDataClearRequest {
+	return &LogDataClearRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDLogDataClearRequest, false, 0, 0, nil),
+	}
+}
+
+// LogDataClearResponse is a log data clear response frame of FA Link frame.
+type LogDataClearResponse struct {
+	*Message
+}
+
+// NewLogDataClearResponse creates a new LogDataClearResponse.
+func NewLogDataClearResponse(sna, dna uint8, vseq, seq uint32) *LogDataClearResponse {
+	return &LogDataClearResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDLogDataClearRequest, true, 0, 0, nil),
+	}
+}
+
+// MessageReturnRequest is a message return request frame of FA Link frame.
+// The destination node returns Data as it is, so that it can be used for connectivity tests.
+type MessageReturnRequest struct {
+	*Message
+}
+
+// NewMessageReturnRequest creates a new MessageReturnRequest.
+func NewMessageReturnRequest(sna, dna uint8, vseq, seq uint32, data []byte) *MessageReturnRequest {
+	return &MessageReturnRequest{
+		Message: newMessage(sna, dna, vseq, seq, TCDMessageReturnRequest, false, 0, uint16(len(data)), data),
+	}
+}
+
+// MessageReturnResponse is a message return response frame of FA Link frame.
+// Data holds the data returned from the request.
+type MessageReturnResponse struct {
+	*Message
+}
+
+// NewMessageReturnResponse creates a new MessageReturnResponse.
+func NewMessageReturnResponse(sna, dna uint8, vseq, seq uint32, data []byte) *MessageReturnResponse {
+	return &MessageReturnResponse{
+		Message: newMessage(sna, dna, vseq, seq, TCDMessageReturnRequest, true, 0, uint16(len(data)), data),
+	}
+}
+
+// Matches reports whether the response is the one to req,
+// which means SEQ is the same and Data is echoed back as it is.
+func (m *MessageReturnResponse) Matches(req *MessageReturnRequest) bool {
+	return m.Header.Seq == req.Header.Seq && bytes.Equal(m.Data, req.Data)
+}
@@ -5,6 +5,8 @@
 package flnet_test
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -231,3 +233,974 @@ func TestCyclic(t *testing.T) {
 		})
 	}
 }
+
+func TestByteBlockRead(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Byte block read request frame",
+			structured:  flnet.NewByteBlockReadRequest(1, 2, 0, 1, 0x1000, 4),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x01, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x04, // ULS, M_SZ
+				0x00, 0x00, 0x10, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xeb, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Byte block read response frame",
+			structured: flnet.NewByteBlockReadResponse(
+				2, 1, 0, 1, 0x1000, []byte{0xde, 0xad, 0xbe, 0xef},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x44, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x01, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x04, // ULS, M_SZ
+				0x00, 0x00, 0x10, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xeb, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x44, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0xde, 0xad, 0xbe, 0xef, // Data
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestByteBlockWrite(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Byte block write request frame",
+			structured: flnet.NewByteBlockWriteRequest(
+				1, 2, 0, 2, 0x2000, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x46, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x02, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x06, // ULS, M_SZ
+				0x00, 0x00, 0x20, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xec, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x46, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06, // Data
+			},
+		},
+		{
+			description: "Byte block write response frame",
+			structured:  flnet.NewByteBlockWriteResponse(2, 1, 0, 2, 0x2000, 6),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x02, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x06, // ULS, M_SZ
+				0x00, 0x00, 0x20, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xec, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestWordBlock(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Word block read request frame",
+			structured:  flnet.NewWordBlockReadRequest(1, 2, 0, 3, 0x0100, 2),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x03, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x02, // ULS, M_SZ
+				0x00, 0x00, 0x01, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xed, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Word block read response frame",
+			structured: flnet.NewWordBlockReadResponse(
+				2, 1, 0, 3, 0x0100, []uint16{0x1234, 0xabcd},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x44, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x03, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x02, // ULS, M_SZ
+				0x00, 0x00, 0x01, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xed, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x44, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x12, 0x34, 0xab, 0xcd, // Data
+			},
+		},
+		{
+			description: "Word block write request frame",
+			structured: flnet.NewWordBlockWriteRequest(
+				1, 2, 0, 4, 0x0200, []uint16{0x0001, 0x0002, 0x0003},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x46, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x04, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x03, // ULS, M_SZ
+				0x00, 0x00, 0x02, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xee, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x46, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x00, 0x01, 0x00, 0x02, 0x00, 0x03, // Data
+			},
+		},
+		{
+			description: "Word block write response frame",
+			structured:  flnet.NewWordBlockWriteResponse(2, 1, 0, 4, 0x0200, 3),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x04, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x03, // ULS, M_SZ
+				0x00, 0x00, 0x02, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xee, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestNetworkParameter(t *testing.T) {
+	param := &flnet.NetworkParameter{
+		NodeName:            "NODE",
+		VendorCode:          "VENDOR",
+		ManufacturerModel:   "MANUF.",
+		Area1Address:        0x0004,
+		Area1Size:           0x0004,
+		Area2Address:        0x0040,
+		Area2Size:           0x0040,
+		TokenWatchdogTime:   0x32,
+		MinFrameInterval:    0x0a,
+		LinkStatus:          0xc0,
+		UpperLayerStatus:    0x8000,
+		RefreshCycleTime:    0x0032,
+		RefreshCycleCurrent: 0x0010,
+		RefreshCycleMax:     0x0020,
+		RefreshCycleMin:     0x0008,
+	}
+	serializedParam := []byte{
+		0x4e, 0x4f, 0x44, 0x45, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, // Node name
+		0x56, 0x45, 0x4e, 0x44, 0x4f, 0x52, 0x20, 0x20, 0x20, 0x20, // Vendor code
+		0x4d, 0x41, 0x4e, 0x55, 0x46, 0x2e, 0x20, 0x20, 0x20, 0x20, // Manufacturer model
+		0x00, 0x04, 0x00, 0x04, // Area1 address, size
+		0x00, 0x40, 0x00, 0x40, // Area2 address, size
+		0x32, 0x0a, 0xc0, 0x00, // TW, MFT, LKS, reserved
+		0x80, 0x00, 0x00, 0x32, // ULS, RCT
+		0x00, 0x10, 0x00, 0x20, // Refresh cycle current, max
+		0x00, 0x08, // Refresh cycle min
+	}
+
+	var testcases = []testCase{
+		{
+			description: "Network parameter read request frame",
+			structured:  flnet.NewNetworkParameterReadRequest(1, 2, 0, 5),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x05, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xef, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Network parameter read response frame",
+			structured:  flnet.NewNetworkParameterReadResponse(2, 1, 0, 5, param),
+			serialized: append([]byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x74, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x05, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x34, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xef, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x74, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			}, serializedParam...),
+		},
+		{
+			description: "Network parameter write request frame",
+			structured:  flnet.NewNetworkParameterWriteRequest(1, 2, 0, 6, param),
+			serialized: append([]byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x74, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x06, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x34, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf0, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x74, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			}, serializedParam...),
+		},
+		{
+			description: "Network parameter write response frame",
+			structured:  flnet.NewNetworkParameterWriteResponse(2, 1, 0, 6),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x06, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf0, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestCommand(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Stop command request frame",
+			structured:  flnet.NewStopCommandRequest(1, 2, 0, 7),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x07, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf1, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Stop command response frame",
+			structured:  flnet.NewStopCommandResponse(2, 1, 0, 7, flnet.ResultNormal),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x07, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf1, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Operation command request frame",
+			structured:  flnet.NewOperationCommandRequest(1, 2, 0, 8),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x08, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf2, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Operation command response frame",
+			structured:  flnet.NewOperationCommandResponse(2, 1, 0, 8, flnet.ResultNotExecutable),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x08, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x02, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf2, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestCommandResponseErr(t *testing.T) {
+	cases := []struct {
+		description string
+		mrlt        uint8
+		err         error
+	}{
+		{"normal", flnet.ResultNormal, nil},
+		{"not supported", flnet.ResultNotSupported, flnet.ErrNotSupported},
+		{"not executable", flnet.ResultNotExecutable, flnet.ErrNotExecutable},
+		{"unknown", 0xff, flnet.ErrUnknownResult},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			s := flnet.NewStopCommandResponse(2, 1, 0, 0, c.mrlt)
+			if got, want := s.Err(), c.err; !errors.Is(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			o := flnet.NewOperationCommandResponse(2, 1, 0, 0, c.mrlt)
+			if got, want := o.Err(), c.err; !errors.Is(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProfileRead(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Profile read request frame",
+			structured:  flnet.NewProfileReadRequest(1, 2, 0, 9),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x09, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf3, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Profile read response frame",
+			structured:  newTestProfileReadResponse(2, 1, 0, 9),
+			serialized: append([]byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0xad, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x09, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x6d, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf3, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0xad, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			}, serializedProfile...),
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestTransparentMessage(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Transparent message request frame",
+			structured: flnet.NewTransparentMessage(
+				1, 2, 0, 10, 1000, false, []byte{0x01, 0x02},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x42, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0a, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x02, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0x03, 0xe8, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x42, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x01, 0x02, // Data
+			},
+		},
+		{
+			description: "Transparent message response frame",
+			structured: flnet.NewTransparentMessage(
+				2, 1, 0, 10, 59999, true, []byte{0x03},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x41, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0a, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x01, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xea, 0x5f, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x41, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x03, // Data
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+
+	if flnet.NewTransparentMessage(1, 2, 0, 0, 1000, false, nil).IsResponse() {
+		t.Error("request is reported as a response")
+	}
+	if !flnet.NewTransparentMessage(2, 1, 0, 0, 1000, true, nil).IsResponse() {
+		t.Error("response is reported as a request")
+	}
+}
+
+func TestLogDataMessages(t *testing.T) {
+	log, serializedLog := newTestLogData()
+
+	var testcases = []testCase{
+		{
+			description: "Log data read request frame",
+			structured:  flnet.NewLogDataReadRequest(1, 2, 0, 11),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0b, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf5, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Log data read response frame",
+			structured:  flnet.NewLogDataReadResponse(2, 1, 0, 11, log),
+			serialized: append([]byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x02, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0b, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x02, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf5, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x02, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			}, serializedLog...),
+		},
+		{
+			description: "Log data clear request frame",
+			structured:  flnet.NewLogDataClearRequest(1, 2, 0, 12),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0c, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf6, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+		{
+			description: "Log data clear response frame",
+			structured:  flnet.NewLogDataClearResponse(2, 1, 0, 12),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x40, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0c, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf6, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x40, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestNilPayload(t *testing.T) {
+	profile, err := flnet.NewProfileReadResponse(2, 1, 0, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := []flnet.FLnet{
+		flnet.NewNetworkParameterReadResponse(2, 1, 0, 1, nil),
+		flnet.NewNetworkParameterWriteRequest(1, 2, 0, 1, nil),
+		profile,
+		flnet.NewLogDataReadResponse(2, 1, 0, 1, nil),
+	}
+
+	for _, f := range frames {
+		b, err := f.MarshalBinary()
+		if err != nil {
+			t.Errorf("%T: %v", f, err)
+			continue
+		}
+		if _, err := flnet.Parse(b); err != nil {
+			t.Errorf("%T: %v", f, err)
+		}
+	}
+}
+
+func TestMessageReturn(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Message return request frame",
+			structured: flnet.NewMessageReturnRequest(
+				1, 2, 0, 13, []byte{0x70, 0x69, 0x6e, 0x67},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x44, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0d, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x04, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf7, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x44, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x70, 0x69, 0x6e, 0x67, // Data
+			},
+		},
+		{
+			description: "Message return response frame",
+			structured: flnet.NewMessageReturnResponse(
+				2, 1, 0, 13, []byte{0x70, 0x69, 0x6e, 0x67},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x44, // TFL
+				0x00, 0x01, 0x00, 0x02, // SA
+				0x00, 0x01, 0x00, 0x01, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x0d, // SEQ
+				0x60, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x04, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xf7, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x44, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x70, 0x69, 0x6e, 0x67, // Data
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestMessageReturnMatches(t *testing.T) {
+	req := flnet.NewMessageReturnRequest(1, 2, 0, 13, []byte{0x70, 0x69, 0x6e, 0x67})
+
+	cases := []struct {
+		description string
+		res         *flnet.MessageReturnResponse
+		matches     bool
+	}{
+		{
+			"echoed",
+			flnet.NewMessageReturnResponse(2, 1, 0, 13, []byte{0x70, 0x69, 0x6e, 0x67}),
+			true,
+		},
+		{
+			"different data",
+			flnet.NewMessageReturnResponse(2, 1, 0, 13, []byte{0x70, 0x6f, 0x6e, 0x67}),
+			false,
+		},
+		{
+			"truncated data",
+			flnet.NewMessageReturnResponse(2, 1, 0, 13, []byte{0x70, 0x69}),
+			false,
+		},
+		{
+			"different SEQ",
+			flnet.NewMessageReturnResponse(2, 1, 0, 14, []byte{0x70, 0x69, 0x6e, 0x67}),
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if got, want := c.res.Matches(req), c.matches; got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParticipationRequest(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Participation request frame",
+			structured:  flnet.NewParticipationRequest(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x60, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0xff, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x00, // SEQ
+				0x00, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x0a, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xea, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x04, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x40, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x60, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x4e, 0x4f, 0x44, 0x45, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, // NDN
+				0x56, 0x45, 0x4e, 0x44, 0x4f, 0x52, 0x20, 0x20, 0x20, 0x20, // VDN
+				0x4d, 0x41, 0x4e, 0x55, 0x46, 0x2e, 0x20, 0x20, 0x20, 0x20, // MSN
+				0x00, 0x00, // Reserved
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestGeneric(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Frame with unknown TCD",
+			structured: &flnet.Generic{
+				Message: &flnet.Message{
+					Header: flnet.NewFALinkHeader(
+						[4]byte{0x46, 0x41, 0x43, 0x4e}, // H_TYPE
+						0x42,                            // TFL
+						1,                               // SA
+						2,                               // DA
+						0,                               // V_SEQ
+						0,                               // SEQ
+						false, true, false,              // M_CTL
+						0, 0, // ULS, M_SZ
+						0,       // M_ADD
+						0, 0, 0, // MFT, M_RLT, reserved
+						64000, 0, // TCD, VER
+						0, 0, // C_AD1, C_SZ1
+						0, 0, // C_AD2, C_SZ2
+						0, 3, true, 0x80, 0, // MODE, P_TYPE, PRI
+						1, 1, 0x42, // CBN, TBN, BSIZE
+						0, 0x32, 0, // LKS, TW, RCT
+					),
+					Data: []byte{0xca, 0xfe},
+				},
+			},
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x42, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x00, // V_SEQ
+				0x00, 0x00, 0x00, 0x00, // SEQ
+				0x40, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfa, 0x00, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x00, // C_AD1, C_SZ1
+				0x00, 0x00, 0x00, 0x00, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x42, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0xca, 0xfe, // Data
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+}
+
+func TestParseTruncated(t *testing.T) {
+	for _, f := range validFrames() {
+		b, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := flnet.Parse(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < len(b); i++ {
+			var pe *flnet.ParseError
+			if _, err := flnet.Parse(b[:i]); !errors.As(err, &pe) {
+				t.Errorf("%T truncated to %d bytes: got %v, want a ParseError", f, i, err)
+			}
+			if err := g.UnmarshalBinary(b[:i]); !errors.As(err, &pe) {
+				t.Errorf("%T.UnmarshalBinary truncated to %d bytes: got %v, want a ParseError", g, i, err)
+			}
+		}
+	}
+
+	t.Run("Trigger frame of 70 bytes", func(t *testing.T) {
+		b, err := flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF.").MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := flnet.Parse(b[:70]); !errors.Is(err, flnet.ErrTooShortToParse) {
+			t.Errorf("got %v, want %v", err, flnet.ErrTooShortToParse)
+		}
+	})
+}
+
+func TestParseGarbage(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	frames := validFrames()
+	for i := 0; i < 10000; i++ {
+		b, err := frames[i%len(frames)].MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b = b[:r.Intn(len(b)+1)]
+		for j := 0; j < 4 && len(b) > 0; j++ {
+			b[r.Intn(len(b))] = byte(r.Intn(256))
+		}
+
+		// Parse must not panic on any input.
+		f, err := flnet.Parse(b)
+		if err != nil {
+			continue
+		}
+		_, _ = f.MarshalBinary()
+	}
+}
+
+func TestTokenWithCyclic(t *testing.T) {
+	var testcases = []testCase{
+		{
+			description: "Token frame with cyclic data",
+			structured: flnet.NewTokenWithCyclic(
+				1, 2, 0x10, 0, 1, 0x40, 1, []byte{0x00, 0x01, 0xab, 0xcd},
+			),
+			serialized: []byte{
+				0x46, 0x41, 0x43, 0x4e, // H_TYPE
+				0x00, 0x00, 0x00, 0x44, // TFL
+				0x00, 0x01, 0x00, 0x01, // SA
+				0x00, 0x01, 0x00, 0x02, // DA
+				0x00, 0x00, 0x00, 0x10, // V_SEQ
+				0x00, 0x00, 0x00, 0x00, // SEQ
+				0x00, 0x00, 0x00, 0x00, // M_CTL
+				0x00, 0x00, 0x00, 0x00, // ULS, M_SZ
+				0x00, 0x00, 0x00, 0x00, // M_ADD
+				0x00, 0x00, 0x00, 0x00, // MFT, M_RLT, reserved
+				0xfd, 0xe8, 0x00, 0x00, // TCD, VER
+				0x00, 0x00, 0x00, 0x01, // C_AD1, C_SZ1
+				0x00, 0x40, 0x00, 0x01, // C_AD2, C_SZ2
+				0x00, 0x31, 0x80, 0x00, // MODE, P_TYPE, PRI
+				0x01, 0x01, 0x00, 0x44, // CBN, TBN, BSIZE
+				0x00, 0x32, 0x00, 0x00, // LKS, TW, RCT
+				0x00, 0x01, 0xab, 0xcd, // Data
+			},
+		},
+	}
+
+	runTestCases(t, testcases)
+
+	tok := testcases[0].structured.(*flnet.Token)
+	if err := tok.Validate(); err != nil {
+		t.Error(err)
+	}
+	if err := tok.MarshalTo(make([]byte, 64)); !errors.Is(err, flnet.ErrTooShortToMarshalBinary) {
+		t.Errorf("MarshalTo: got %v, want %v", err, flnet.ErrTooShortToMarshalBinary)
+	}
+	if got, err := tok.Area1Bit(0, 0); err != nil || !got {
+		t.Errorf("Area1Bit: got %v, %v, want true", got, err)
+	}
+	if got, err := tok.Area2Word(0x40); err != nil || got != 0xabcd {
+		t.Errorf("Area2Word: got %#04x, %v, want 0xabcd", got, err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// NetworkParameter is the network parameter of a node,
+// which is carried by the network parameter read/write messages.
+type NetworkParameter struct {
+	NodeName            string           `json:"node_name"`
+	VendorCode          string           `json:"vendor_code"`
+	ManufacturerModel   string           `json:"manufacturer_model"`
+	Area1Address        uint16           `json:"area1_address"`
+	Area1Size           uint16           `json:"area1_size"`
+	Area2Address        uint16           `json:"area2_address"`
+	Area2Size           uint16           `json:"area2_size"`
+	TokenWatchdogTime   uint8            `json:"token_watchdog_time"`
+	MinFrameInterval    uint8            `json:"min_frame_interval"`
+	LinkStatus          LinkStatus       `json:"link_status"`
+	UpperLayerStatus    UpperLayerStatus `json:"upper_layer_status"`
+	RefreshCycleTime    uint16           `json:"refresh_cycle_time"`
+	RefreshCycleCurrent uint16           `json:"refresh_cycle_current"`
+	RefreshCycleMax     uint16           `json:"refresh_cycle_max"`
+	RefreshCycleMin     uint16           `json:"refresh_cycle_min"`
+}
+
+// MarshalBinary returns the byte sequence generated from a NetworkParameter.
+func (p *NetworkParameter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, p.MarshalLen())
+	if err := p.MarshalTo(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+// Names are padded with spaces up to 10 bytes.
+func (p *NetworkParameter) MarshalTo(b []byte) error {
+	l := len(b)
+	if l < p.MarshalLen() {
+		return io.ErrUnexpectedEOF
+	}
+
+	putName(b[0:10], p.NodeName)
+	putName(b[10:20], p.VendorCode)
+	putName(b[20:30], p.ManufacturerModel)
+	binary.BigEndian.PutUint16(b[30:], p.Area1Address)
+	binary.BigEndian.PutUint16(b[32:], p.Area1Size)
+	binary.BigEndian.PutUint16(b[34:], p.Area2Address)
+	binary.BigEndian.PutUint16(b[36:], p.Area2Size)
+	b[38] = p.TokenWatchdogTime
+	b[39] = p.MinFrameInterval
+	b[40] = uint8(p.LinkStatus)
+	b[41] = 0
+	binary.BigEndian.PutUint16(b[42:], uint16(p.UpperLayerStatus))
+	binary.BigEndian.PutUint16(b[44:], p.RefreshCycleTime)
+	binary.BigEndian.PutUint16(b[46:], p.RefreshCycleCurrent)
+	binary.BigEndian.PutUint16(b[48:], p.RefreshCycleMax)
+	binary.BigEndian.PutUint16(b[50:], p.RefreshCycleMin)
+
+	return nil
+}
+
+// MarshalLen returns the serial length of NetworkParameter.
+func (p *NetworkParameter) MarshalLen() int {
+	return 52
+}
+
+// UnmarshalBinary sets the values retrieved from byte sequence in a NetworkParameter.
+func (p *NetworkParameter) UnmarshalBinary(b []byte) error {
+	if len(b) < p.MarshalLen() {
+		return errTooShort("network parameter", p.MarshalLen(), b)
+	}
+
+	p.NodeName = name(b[0:10])
+	p.VendorCode = name(b[10:20])
+	p.ManufacturerModel = name(b[20:30])
+	p.Area1Address = binary.BigEndian.Uint16(b[30:32])
+	p.Area1Size = binary.BigEndian.Uint16(b[32:34])
+	p.Area2Address = binary.BigEndian.Uint16(b[34:36])
+	p.Area2Size = binary.BigEndian.Uint16(b[36:38])
+	p.TokenWatchdogTime = uint8(b[38])
+	p.MinFrameInterval = uint8(b[39])
+	p.LinkStatus = LinkStatus(b[40])
+	p.UpperLayerStatus = UpperLayerStatus(binary.BigEndian.Uint16(b[42:44]))
+	p.RefreshCycleTime = binary.BigEndian.Uint16(b[44:46])
+	p.RefreshCycleCurrent = binary.BigEndian.Uint16(b[46:48])
+	p.RefreshCycleMax = binary.BigEndian.Uint16(b[48:50])
+	p.RefreshCycleMin = binary.BigEndian.Uint16(b[50:52])
+
+	return nil
+}
+
+// putName puts s in b padding the rest with spaces.
+func putName(b []byte, s string) {
+	n := copy(b, s)
+	for i := n; i < len(b); i++ {
+		b[i] = 0x20
+	}
+}
+
+// name returns the string in b without trailing spaces and NULs.
+func name(b []byte) string {
+	return string(bytes.TrimRight(b, "\x20\x00"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// Node number definitions.
+const (
+	NodeMin       uint8 = 1
+	NodeMax       uint8 = 254
+	NodeBroadcast uint8 = 255
+)
+
+// nodeNetwork is the conventional network of FL-net nodes, 192.168.250.0/24.
+var nodeNetwork = net.IPv4(192, 168, 250, 0)
+
+// nodeAddress returns the value of SA or DA for the node number n.
+func nodeAddress(n uint8) uint32 {
+	return 0x00010000 | uint32(n)
+}
+
+// ValidateNode returns ErrInvalidNode if n is not a node number from NodeMin to NodeMax.
+func ValidateNode(n uint8) error {
+	if n < NodeMin || n > NodeMax {
+		return errors.Wrapf(ErrInvalidNode, "node number %d", n)
+	}
+	return nil
+}
+
+// NodeIP returns the conventional IP address of the node number n, 192.168.250.n.
+// NodeBroadcast gives the broadcast address of the network.
+func NodeIP(n uint8) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	copy(ip, nodeNetwork.To4())
+	ip[3] = n
+	return ip
+}
+
+// NodeFromIP returns the node number of the conventional IP address ip.
+// It returns ErrInvalidNode if ip is not in 192.168.250.0/24 or
+// the last octet is not a valid node number.
+func NodeFromIP(ip net.IP) (uint8, error) {
+	ip4 := ip.To4()
+	if ip4 == nil || !ip4.Mask(net.CIDRMask(24, 32)).Equal(nodeNetwork) {
+		return 0, errors.Wrapf(ErrInvalidNode, "IP address %v", ip)
+	}
+
+	n := ip4[3]
+	if err := ValidateNode(n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// SourceNode returns the node number in SA.
+func (h *FALinkHeader) SourceNode() uint8 {
+	return uint8(h.SA)
+}
+
+// DestNode returns the node number in DA.
+func (h *FALinkHeader) DestNode() uint8 {
+	return uint8(h.DA)
+}
+
+// IsBroadcast reports whether the frame is destined to all the nodes.
+func (h *FALinkHeader) IsBroadcast() bool {
+	return h.DestNode() == NodeBroadcast
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestValidateNode(t *testing.T) {
+	cases := []struct {
+		node  uint8
+		valid bool
+	}{
+		{0, false},
+		{1, true},
+		{128, true},
+		{254, true},
+		{255, false},
+	}
+
+	for _, c := range cases {
+		err := flnet.ValidateNode(c.node)
+		if got, want := err == nil, c.valid; got != want {
+			t.Errorf("node %d: got %v, want valid=%v", c.node, err, want)
+		}
+		if err != nil && !errors.Is(err, flnet.ErrInvalidNode) {
+			t.Errorf("node %d: got %v, want %v", c.node, err, flnet.ErrInvalidNode)
+		}
+	}
+}
+
+func TestNodeIP(t *testing.T) {
+	if got, want := flnet.NodeIP(1), net.IPv4(192, 168, 250, 1); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := flnet.NodeIP(flnet.NodeBroadcast), net.IPv4(192, 168, 250, 255); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	cases := []struct {
+		description string
+		ip          net.IP
+		node        uint8
+		valid       bool
+	}{
+		{"node 1", net.IPv4(192, 168, 250, 1), 1, true},
+		{"node 254 in 4-byte form", net.IP{192, 168, 250, 254}, 254, true},
+		{"network address", net.IPv4(192, 168, 250, 0), 0, false},
+		{"broadcast address", net.IPv4(192, 168, 250, 255), 0, false},
+		{"other network", net.IPv4(192, 168, 1, 1), 0, false},
+		{"IPv6", net.ParseIP("fe80::1"), 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			n, err := flnet.NodeFromIP(c.ip)
+			if got, want := err == nil, c.valid; got != want {
+				t.Fatalf("got %v, want valid=%v", err, want)
+			}
+			if err != nil && !errors.Is(err, flnet.ErrInvalidNode) {
+				t.Errorf("got %v, want %v", err, flnet.ErrInvalidNode)
+			}
+			if got, want := n, c.node; got != want {
+				t.Errorf("got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestHeaderNodes(t *testing.T) {
+	h := flnet.NewHeader(flnet.WithSource(1), flnet.WithDest(flnet.NodeBroadcast))
+	if got, want := h.SourceNode(), uint8(1); got != want {
+		t.Errorf("SourceNode: got %d, want %d", got, want)
+	}
+	if got, want := h.DestNode(), flnet.NodeBroadcast; got != want {
+		t.Errorf("DestNode: got %d, want %d", got, want)
+	}
+	if !h.IsBroadcast() {
+		t.Error("broadcast frame is not reported as broadcast")
+	}
+
+	h = flnet.NewHeader(flnet.WithSource(2), flnet.WithDest(3))
+	if h.IsBroadcast() {
+		t.Error("unicast frame is reported as broadcast")
+	}
+}
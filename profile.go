@@ -0,0 +1,162 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"encoding/asn1"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Parameter names of the system parameters of a device profile.
+const (
+	profileComVersion = "COMVERSION"
+	profileID         = "ID"
+	profileRev        = "REV"
+	profileRevDate    = "REVDATE"
+	profileDvCategory = "DVCATEGORY"
+	profileVendor     = "VENDOR"
+	profileDvModel    = "DVMODEL"
+)
+
+// ProfileID is the value of ID in the system parameters of a device profile.
+const ProfileID = "SYSPARA"
+
+// ProfileDate is a date used in a device profile.
+type ProfileDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// DeviceProfile is the system parameters of a FL-net device profile.
+type DeviceProfile struct {
+	ComVersion     int         `json:"com_version"`
+	ID             string      `json:"id"`
+	Rev            int         `json:"rev"`
+	RevDate        ProfileDate `json:"rev_date"`
+	DeviceCategory string      `json:"device_category"`
+	Vendor         string      `json:"vendor"`
+	DeviceModel    string      `json:"device_model"`
+}
+
+// sysParameter is the ASN.1 structure of the system parameters,
+// in which every parameter value is preceded by its name.
+type sysParameter struct {
+	ComVersionName string `asn1:"printable"`
+	ComVersion     int
+	IDName         string `asn1:"printable"`
+	ID             string `asn1:"printable"`
+	RevName        string `asn1:"printable"`
+	Rev            int
+	RevDateName    string `asn1:"printable"`
+	RevDate        ProfileDate
+	DvCategoryName string `asn1:"printable"`
+	DvCategory     string `asn1:"printable"`
+	VendorName     string `asn1:"printable"`
+	Vendor         string `asn1:"printable"`
+	DvModelName    string `asn1:"printable"`
+	DvModel        string `asn1:"printable"`
+}
+
+// NewDeviceProfile creates a new DeviceProfile.
+func NewDeviceProfile(comVersion, rev int, revDate ProfileDate, dvCategory, vendor, dvModel string) *DeviceProfile {
+	return &DeviceProfile{
+		ComVersion:     comVersion,
+		ID:             ProfileID,
+		Rev:            rev,
+		RevDate:        revDate,
+		DeviceCategory: dvCategory,
+		Vendor:         vendor,
+		DeviceModel:    dvModel,
+	}
+}
+
+// MarshalBinary returns the BER encoded byte sequence generated from a DeviceProfile.
+// The names must consist of the characters of PrintableString, otherwise
+// an error wrapping ErrInvalidProfile is returned.
+func (p *DeviceProfile) MarshalBinary() ([]byte, error) {
+	b, err := asn1.Marshal(sysParameter{
+		ComVersionName: profileComVersion,
+		ComVersion:     p.ComVersion,
+		IDName:         profileID,
+		ID:             p.ID,
+		RevName:        profileRev,
+		Rev:            p.Rev,
+		RevDateName:    profileRevDate,
+		RevDate:        p.RevDate,
+		DvCategoryName: profileDvCategory,
+		DvCategory:     p.DeviceCategory,
+		VendorName:     profileVendor,
+		Vendor:         p.Vendor,
+		DvModelName:    profileDvModel,
+		DvModel:        p.DeviceModel,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidProfile, "failed to encode: %v", err)
+	}
+
+	return b, nil
+}
+
+// MarshalTo puts the byte sequence in the byte array given as b.
+func (p *DeviceProfile) MarshalTo(b []byte) error {
+	e, err := p.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if len(b) < len(e) {
+		return io.ErrUnexpectedEOF
+	}
+	copy(b, e)
+
+	return nil
+}
+
+// MarshalLen returns the serial length of DeviceProfile.
+// It returns 0 if the DeviceProfile cannot be encoded.
+func (p *DeviceProfile) MarshalLen() int {
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// UnmarshalBinary sets the values retrieved from BER encoded byte sequence in a DeviceProfile.
+func (p *DeviceProfile) UnmarshalBinary(b []byte) error {
+	var s sysParameter
+	if _, err := asn1.Unmarshal(b, &s); err != nil {
+		return errors.Wrap(ErrInvalidProfile, err.Error())
+	}
+
+	names := []struct {
+		got, want string
+	}{
+		{s.ComVersionName, profileComVersion},
+		{s.IDName, profileID},
+		{s.RevName, profileRev},
+		{s.RevDateName, profileRevDate},
+		{s.DvCategoryName, profileDvCategory},
+		{s.VendorName, profileVendor},
+		{s.DvModelName, profileDvModel},
+	}
+	for _, n := range names {
+		if n.got != n.want {
+			return errors.Wrapf(ErrInvalidProfile, "got parameter %q, want %q", n.got, n.want)
+		}
+	}
+
+	p.ComVersion = s.ComVersion
+	p.ID = s.ID
+	p.Rev = s.Rev
+	p.RevDate = s.RevDate
+	p.DeviceCategory = s.DvCategory
+	p.Vendor = s.Vendor
+	p.DeviceModel = s.DvModel
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
+var serializedProfile = []byte{
+	0x30, 0x6b, // SEQUENCE
+	0x13, 0x0a, 0x43, 0x4f, 0x4d, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, // "COMVERSION"
+	0x02, 0x01, 0x01, // 1
+	0x13, 0x02, 0x49, 0x44, // "ID"
+	0x13, 0x07, 0x53, 0x59, 0x53, 0x50, 0x41, 0x52, 0x41, // "SYSPARA"
+	0x13, 0x03, 0x52, 0x45, 0x56, // "REV"
+	0x02, 0x01, 0x00, // 0
+	0x13, 0x07, 0x52, 0x45, 0x56, 0x44, 0x41, 0x54, 0x45, // "REVDATE"
+	0x30, 0x0a, 0x02, 0x02, 0x07, 0xe4, 0x02, 0x01, 0x08, 0x02, 0x01, 0x01, // 2020, 8, 1
+	0x13, 0x0a, 0x44, 0x56, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, // "DVCATEGORY"
+	0x13, 0x03, 0x50, 0x4c, 0x43, // "PLC"
+	0x13, 0x06, 0x56, 0x45, 0x4e, 0x44, 0x4f, 0x52, // "VENDOR"
+	0x13, 0x06, 0x56, 0x45, 0x4e, 0x44, 0x4f, 0x52, // "VENDOR"
+	0x13, 0x07, 0x44, 0x56, 0x4d, 0x4f, 0x44, 0x45, 0x4c, // "DVMODEL"
+	0x13, 0x06, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x31, // "MODEL1"
+}
+
+func newTestProfile() *flnet.DeviceProfile {
+	return flnet.NewDeviceProfile(
+		1, 0, flnet.ProfileDate{Year: 2020, Month: 8, Day: 1}, "PLC", "VENDOR", "MODEL1",
+	)
+}
+
+// newTestProfileReadResponse creates a ProfileReadResponse of newTestProfile.
+func newTestProfileReadResponse(sna, dna uint8, vseq, seq uint32) *flnet.ProfileReadResponse {
+	p, err := flnet.NewProfileReadResponse(sna, dna, vseq, seq, newTestProfile())
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestDeviceProfile(t *testing.T) {
+	t.Run("Decode", func(t *testing.T) {
+		got := &flnet.DeviceProfile{}
+		if err := got.UnmarshalBinary(serializedProfile); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(newTestProfile(), got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+	t.Run("Serialize", func(t *testing.T) {
+		got, err := newTestProfile().MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(serializedProfile, got); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+	t.Run("Wrong parameter name", func(t *testing.T) {
+		b := append([]byte{}, serializedProfile...)
+		b[4] = 0x58 // "CXMVERSION"
+		err := (&flnet.DeviceProfile{}).UnmarshalBinary(b)
+		if !errors.Is(err, flnet.ErrInvalidProfile) {
+			t.Errorf("got %v, want %v", err, flnet.ErrInvalidProfile)
+		}
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		err := (&flnet.DeviceProfile{}).UnmarshalBinary(serializedProfile[:20])
+		if !errors.Is(err, flnet.ErrInvalidProfile) {
+			t.Errorf("got %v, want %v", err, flnet.ErrInvalidProfile)
+		}
+	})
+	t.Run("Changed after encoding", func(t *testing.T) {
+		p := newTestProfile()
+		if _, err := p.MarshalBinary(); err != nil {
+			t.Fatal(err)
+		}
+		p.DeviceModel = "MODEL12"
+		b, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := p.MarshalLen(), len(serializedProfile)+1; got != want || len(b) != want {
+			t.Errorf("got %d bytes and MarshalLen %d, want %d", len(b), got, want)
+		}
+	})
+	t.Run("Not printable", func(t *testing.T) {
+		p := newTestProfile()
+		p.Vendor = "VENDOR_1"
+		if _, err := p.MarshalBinary(); !errors.Is(err, flnet.ErrInvalidProfile) {
+			t.Errorf("got %v, want %v", err, flnet.ErrInvalidProfile)
+		}
+		if _, err := flnet.NewProfileReadResponse(2, 1, 0, 0, p); !errors.Is(err, flnet.ErrInvalidProfile) {
+			t.Errorf("NewProfileReadResponse: got %v, want %v", err, flnet.ErrInvalidProfile)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import "sync"
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[uint16]func() FLnet{}
+)
+
+// RegisterDecoder makes Parse decode the frames with the TCD as the type
+// returned by newFrame, instead of the built-in one.
+// newFrame is called for every frame and must return a new empty frame
+// which is ready to UnmarshalBinary.
+// Registering a nil newFrame removes the decoder for the TCD.
+func RegisterDecoder(tcd uint16, newFrame func() FLnet) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	if newFrame == nil {
+		delete(decoders, tcd)
+		return
+	}
+	decoders[tcd] = newFrame
+}
+
+// registeredFrame returns a new frame from the decoder registered for the TCD,
+// or nil if there is no such decoder.
+func registeredFrame(tcd uint16) FLnet {
+	decodersMu.RLock()
+	newFrame, ok := decoders[tcd]
+	decodersMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	return newFrame()
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+// vendorFrame is a vendor-specific frame carrying a single word.
+type vendorFrame struct {
+	Header *flnet.FALinkHeader
+	Value  uint16
+}
+
+func (v *vendorFrame) MarshalBinary() ([]byte, error) {
+	b := make([]byte, v.MarshalLen())
+	if err := v.MarshalTo(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (v *vendorFrame) MarshalTo(b []byte) error {
+	if err := v.Header.MarshalTo(b); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint16(b[v.Header.MarshalLen():], v.Value)
+	return nil
+}
+
+func (v *vendorFrame) MarshalLen() int {
+	return v.Header.MarshalLen() + 2
+}
+
+func (v *vendorFrame) UnmarshalBinary(b []byte) error {
+	if err := v.Header.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	if len(b) < v.MarshalLen() {
+		return flnet.ErrTooShortToParse
+	}
+	v.Value = binary.BigEndian.Uint16(b[v.Header.MarshalLen():])
+	return nil
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	const tcd = 64000
+
+	b, err := flnet.NewTransparentMessage(1, 2, 0, 0, 1000, false, []byte{0x12, 0x34}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.BigEndian.PutUint16(b[40:42], tcd)
+
+	t.Run("Registered", func(t *testing.T) {
+		flnet.RegisterDecoder(tcd, func() flnet.FLnet {
+			return &vendorFrame{Header: &flnet.FALinkHeader{}}
+		})
+		defer flnet.RegisterDecoder(tcd, nil)
+
+		f, err := flnet.Parse(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, ok := f.(*vendorFrame)
+		if !ok {
+			t.Fatalf("got %T, want *vendorFrame", f)
+		}
+		if got, want := v.Value, uint16(0x1234); got != want {
+			t.Errorf("got %#x, want %#x", got, want)
+		}
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		flnet.RegisterDecoder(tcd, func() flnet.FLnet {
+			return &vendorFrame{Header: &flnet.FALinkHeader{}}
+		})
+		defer flnet.RegisterDecoder(tcd, nil)
+		d := flnet.NewDecoder(flnet.AliasPayload)
+
+		first, err := d.Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next := append([]byte(nil), b...)
+		binary.BigEndian.PutUint16(next[64:66], 0x5678)
+		second, err := d.Decode(next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first == second {
+			t.Fatal("the frame of the registered decoder is reused")
+		}
+		if got, want := first.(*vendorFrame).Value, uint16(0x1234); got != want {
+			t.Errorf("got %#x, want %#x", got, want)
+		}
+		if got, want := second.(*vendorFrame).Value, uint16(0x5678); got != want {
+			t.Errorf("got %#x, want %#x", got, want)
+		}
+
+		flnet.RegisterDecoder(tcd, nil)
+		f, err := d.Decode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := f.(*flnet.Generic); !ok {
+			t.Errorf("got %T after unregistering, want *flnet.Generic", f)
+		}
+	})
+	t.Run("Unregistered", func(t *testing.T) {
+		f, err := flnet.Parse(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := f.(*flnet.Generic); !ok {
+			t.Errorf("got %T, want *flnet.Generic", f)
+		}
+	})
+	t.Run("Overriding built-in type", func(t *testing.T) {
+		flnet.RegisterDecoder(flnet.TCDCyclic, func() flnet.FLnet {
+			return &flnet.Generic{Message: &flnet.Message{Header: &flnet.FALinkHeader{}}}
+		})
+		defer flnet.RegisterDecoder(flnet.TCDCyclic, nil)
+
+		c, err := flnet.NewCyclic(1, 2, 0, 0, 0, 0, 0, nil).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := flnet.Parse(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := f.(*flnet.Generic); !ok {
+			t.Errorf("got %T, want *flnet.Generic", f)
+		}
+	})
+}
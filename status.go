@@ -0,0 +1,80 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+// UpperLayerStatus is the upper layer status (ULS) of a node.
+type UpperLayerStatus uint16
+
+// ULS bit fields.
+const (
+	ULSRun     UpperLayerStatus = 0x8000
+	ULSErr     UpperLayerStatus = 0x4000
+	ULSErrCode UpperLayerStatus = 0x0fff
+)
+
+// NewUpperLayerStatus creates a new UpperLayerStatus.
+// code is truncated to 12 bits of U_ERR_CODE.
+func NewUpperLayerStatus(run, err bool, code uint16) UpperLayerStatus {
+	var u UpperLayerStatus
+	if run {
+		u |= ULSRun
+	}
+	if err {
+		u |= ULSErr
+	}
+	return u | UpperLayerStatus(code)&ULSErrCode
+}
+
+// Running reports whether the upper layer is in RUN, or in STOP otherwise.
+func (u UpperLayerStatus) Running() bool {
+	return u&ULSRun != 0
+}
+
+// HasError reports whether the upper layer is in an error state.
+func (u UpperLayerStatus) HasError() bool {
+	return u&ULSErr != 0
+}
+
+// ErrCode returns U_ERR_CODE, the error code defined by the upper layer.
+func (u UpperLayerStatus) ErrCode() uint16 {
+	return uint16(u & ULSErrCode)
+}
+
+// LinkStatus is the link status (LKS) of a node.
+type LinkStatus uint8
+
+// LKS bit fields.
+const (
+	LKSJoined            LinkStatus = 0x80
+	LKSUpperLayerError   LinkStatus = 0x40
+	LKSCommonMemoryValid LinkStatus = 0x20
+	LKSCommonMemorySet   LinkStatus = 0x10
+	LKSAddressOverlap    LinkStatus = 0x08
+)
+
+// Joined reports whether the node participates in the network.
+func (l LinkStatus) Joined() bool {
+	return l&LKSJoined != 0
+}
+
+// UpperLayerError reports whether the operating signal of the upper layer is abnormal.
+func (l LinkStatus) UpperLayerError() bool {
+	return l&LKSUpperLayerError != 0
+}
+
+// CommonMemoryValid reports whether the data in the common memory is valid.
+func (l LinkStatus) CommonMemoryValid() bool {
+	return l&LKSCommonMemoryValid != 0
+}
+
+// CommonMemorySet reports whether the common memory setting is completed.
+func (l LinkStatus) CommonMemorySet() bool {
+	return l&LKSCommonMemorySet != 0
+}
+
+// AddressOverlap reports whether an overlap of the common memory addresses is detected.
+func (l LinkStatus) AddressOverlap() bool {
+	return l&LKSAddressOverlap != 0
+}
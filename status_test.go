@@ -0,0 +1,64 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+func TestUpperLayerStatus(t *testing.T) {
+	cases := []struct {
+		description string
+		uls         flnet.UpperLayerStatus
+		running     bool
+		hasError    bool
+		errCode     uint16
+	}{
+		{"stop", 0x0000, false, false, 0},
+		{"run", 0x8000, true, false, 0},
+		{"run with error", 0xc005, true, true, 5},
+		{"stop with error code", 0x4fff, false, true, 0xfff},
+		{"reserved bits", 0x3000, false, false, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			if got, want := c.uls.Running(), c.running; got != want {
+				t.Errorf("Running: got %v, want %v", got, want)
+			}
+			if got, want := c.uls.HasError(), c.hasError; got != want {
+				t.Errorf("HasError: got %v, want %v", got, want)
+			}
+			if got, want := c.uls.ErrCode(), c.errCode; got != want {
+				t.Errorf("ErrCode: got %#x, want %#x", got, want)
+			}
+			if got, want := flnet.NewUpperLayerStatus(c.running, c.hasError, c.errCode), c.uls&^0x3000; got != want {
+				t.Errorf("NewUpperLayerStatus: got %#x, want %#x", got, want)
+			}
+		})
+	}
+}
+
+func TestLinkStatus(t *testing.T) {
+	l := flnet.LKSJoined | flnet.LKSCommonMemorySet
+
+	if got, want := l.Joined(), true; got != want {
+		t.Errorf("Joined: got %v, want %v", got, want)
+	}
+	if got, want := l.UpperLayerError(), false; got != want {
+		t.Errorf("UpperLayerError: got %v, want %v", got, want)
+	}
+	if got, want := l.CommonMemoryValid(), false; got != want {
+		t.Errorf("CommonMemoryValid: got %v, want %v", got, want)
+	}
+	if got, want := l.CommonMemorySet(), true; got != want {
+		t.Errorf("CommonMemorySet: got %v, want %v", got, want)
+	}
+	if got, want := l.AddressOverlap(), false; got != want {
+		t.Errorf("AddressOverlap: got %v, want %v", got, want)
+	}
+}
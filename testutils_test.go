@@ -4,6 +4,13 @@
 
 package flnet_test
 
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kazukiigeta/go-flnet"
+)
+
 type serializeable interface {
 	MarshalBinary() ([]byte, error)
 	MarshalLen() int
@@ -14,3 +21,32 @@ type testCase = struct {
 	structured  serializeable
 	serialized  []byte
 }
+
+func runTestCases(t *testing.T, testcases []testCase) {
+	t.Helper()
+	for _, c := range testcases {
+		c := c
+		t.Run(c.description, func(t *testing.T) {
+			t.Run("Decode", func(t *testing.T) {
+				msg, err := flnet.Parse(c.serialized)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, want := msg, c.structured
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("differs: (-want +got)\n%s", diff)
+				}
+			})
+			t.Run("Serialize", func(t *testing.T) {
+				b, err := c.structured.MarshalBinary()
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, want := b, c.serialized
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("differs: (-want +got)\n%s", diff)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Size limits of the common memory in words.
+const (
+	Area1Words = 512
+	Area2Words = 8192
+)
+
+// ValidationError is returned by Validate when a frame does not conform to the specification.
+// It can be checked with errors.Is against ErrInvalidFrame.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidFrame.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidFrame
+}
+
+// invalid returns a new ValidationError.
+func invalid(field, format string, a ...interface{}) error {
+	return &ValidationError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, a...),
+	}
+}
+
+// Validate checks whether the fields of a FL-net common header conform to the specification.
+// The lengths are checked by Validate of the frames, as they depend on the data.
+func (h *FALinkHeader) Validate() error {
+	if !bytes.Equal(h.HType[:], hTypeFACN[:]) {
+		return invalid("H_TYPE", "got %q, want %q", h.HType[:], hTypeFACN[:])
+	}
+	if h.SA>>16 != 1 {
+		return invalid("SA", "got %#08x, want 0x0001 in the upper 16 bits", h.SA)
+	}
+	if err := ValidateNode(h.SourceNode()); err != nil {
+		return invalid("SA", "node number %d out of range %d-%d", h.SourceNode(), NodeMin, NodeMax)
+	}
+	if h.DA>>16 != 1 {
+		return invalid("DA", "got %#08x, want 0x0001 in the upper 16 bits", h.DA)
+	}
+	if err := ValidateNode(h.DestNode()); err != nil && !h.IsBroadcast() {
+		return invalid("DA", "node number %d out of range %d-%d", h.DestNode(), NodeMin, NodeBroadcast)
+	}
+	if int(h.CAD1)+int(h.CSZ1) > Area1Words {
+		return invalid("C_SZ1", "area 1 from %d of %d words exceeds %d words", h.CAD1, h.CSZ1, Area1Words)
+	}
+	if int(h.CAD2)+int(h.CSZ2) > Area2Words {
+		return invalid("C_SZ2", "area 2 from %d of %d words exceeds %d words", h.CAD2, h.CSZ2, Area2Words)
+	}
+	if h.CBN < 1 || h.CBN > h.TBN {
+		return invalid("CBN", "got %d, want 1-%d", h.CBN, h.TBN)
+	}
+	if int(h.BSize) < h.MarshalLen() {
+		return invalid("BSIZE", "got %d, shorter than the header", h.BSize)
+	}
+	if h.TFL < uint32(h.BSize) {
+		return invalid("TFL", "got %d, shorter than BSIZE %d", h.TFL, h.BSize)
+	}
+
+	return nil
+}
+
+// validateFrame checks the header of a frame of l bytes, whose TCD and RPL must be tcd and rpl.
+// TFL and BSIZE must be equal to l.
+func validateFrame(h *FALinkHeader, l int, tcd uint16, rpl bool) error {
+	if err := validateBlock(h, l); err != nil {
+		return err
+	}
+	if h.TCD != tcd {
+		return invalid("TCD", "got %d, want %d", h.TCD, tcd)
+	}
+	if h.RPL() != rpl {
+		return invalid("M_CTL", "got RPL=%v, want %v", h.RPL(), rpl)
+	}
+	if h.TFL != uint32(l) {
+		return invalid("TFL", "got %d, want %d", h.TFL, l)
+	}
+
+	return nil
+}
+
+// validateBlock checks the header of a block of l bytes.
+func validateBlock(h *FALinkHeader, l int) error {
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	if int(h.BSize) != l {
+		return invalid("BSIZE", "got %d, want %d", h.BSize, l)
+	}
+
+	return nil
+}
+
+// validateMSZ checks whether M_SZ is equal to msz.
+func validateMSZ(h *FALinkHeader, msz int) error {
+	if int(h.MSZ) != msz {
+		return invalid("M_SZ", "got %d, want %d", h.MSZ, msz)
+	}
+
+	return nil
+}
+
+// validateCyclicSize checks whether BSIZE is equal to the length of the header
+// and the cyclic data of C_SZ1 and C_SZ2 words.
+func validateCyclicSize(h *FALinkHeader) error {
+	if l := h.MarshalLen() + 2*(int(h.CSZ1)+int(h.CSZ2)); int(h.BSize) != l {
+		return invalid("BSIZE", "got %d, want %d for C_SZ1 %d and C_SZ2 %d", h.BSize, l, h.CSZ1, h.CSZ2)
+	}
+
+	return nil
+}
+
+// Validate checks whether the frame conforms to the specification.
+// TFL may be longer than the frame, as the token can carry the last block
+// of the cyclic data split into blocks.
+func (t *Token) Validate() error {
+	if err := validateBlock(t.Header, t.MarshalLen()); err != nil {
+		return err
+	}
+	if t.Header.TCD != TCDToken {
+		return invalid("TCD", "got %d, want %d", t.Header.TCD, TCDToken)
+	}
+	if len(t.Data) > 0 {
+		return validateCyclicSize(t.Header)
+	}
+
+	return nil
+}
+
+// Validate checks whether the frame conforms to the specification.
+// TFL may be longer than the frame, as the cyclic data can be split into blocks.
+func (c *Cyclic) Validate() error {
+	if err := validateBlock(c.Header, c.MarshalLen()); err != nil {
+		return err
+	}
+	if c.Header.TCD != TCDCyclic {
+		return invalid("TCD", "got %d, want %d", c.Header.TCD, TCDCyclic)
+	}
+
+	return validateCyclicSize(c.Header)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (t *Trigger) Validate() error {
+	return validateFrame(t.Header, t.MarshalLen(), TCDTrigger, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (p *ParticipationRequest) Validate() error {
+	return validateFrame(p.Header, p.MarshalLen(), TCDParticipationRequest, false)
+}
+
+// Validate checks whether the header and the length of the frame conform to the specification.
+func (m *Message) Validate() error {
+	if err := validateBlock(m.Header, m.MarshalLen()); err != nil {
+		return err
+	}
+	if m.Header.TFL != uint32(m.MarshalLen()) {
+		return invalid("TFL", "got %d, want %d", m.Header.TFL, m.MarshalLen())
+	}
+
+	return nil
+}
+
+// Validate checks whether the header and the length of the frame conform to the specification.
+func (w *WordMessage) Validate() error {
+	if err := validateBlock(w.Header, w.MarshalLen()); err != nil {
+		return err
+	}
+	if w.Header.TFL != uint32(w.MarshalLen()) {
+		return invalid("TFL", "got %d, want %d", w.Header.TFL, w.MarshalLen())
+	}
+
+	return nil
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (g *Generic) Validate() error {
+	return g.Message.Validate()
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (b *ByteBlockReadRequest) Validate() error {
+	return validateFrame(b.Header, b.MarshalLen(), TCDByteBlockReadRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (b *ByteBlockReadResponse) Validate() error {
+	if err := validateFrame(b.Header, b.MarshalLen(), TCDByteBlockReadRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(b.Header, len(b.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (b *ByteBlockWriteRequest) Validate() error {
+	if err := validateFrame(b.Header, b.MarshalLen(), TCDByteBlockWriteRequest, false); err != nil {
+		return err
+	}
+	return validateMSZ(b.Header, len(b.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (b *ByteBlockWriteResponse) Validate() error {
+	return validateFrame(b.Header, b.MarshalLen(), TCDByteBlockWriteRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (w *WordBlockReadRequest) Validate() error {
+	return validateFrame(w.Header, w.MarshalLen(), TCDWordBlockReadRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (w *WordBlockReadResponse) Validate() error {
+	if err := validateFrame(w.Header, w.MarshalLen(), TCDWordBlockReadRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(w.Header, len(w.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (w *WordBlockWriteRequest) Validate() error {
+	if err := validateFrame(w.Header, w.MarshalLen(), TCDWordBlockWriteRequest, false); err != nil {
+		return err
+	}
+	return validateMSZ(w.Header, len(w.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (w *WordBlockWriteResponse) Validate() error {
+	return validateFrame(w.Header, w.MarshalLen(), TCDWordBlockWriteRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (n *NetworkParameterReadRequest) Validate() error {
+	return validateFrame(n.Header, n.MarshalLen(), TCDNetworkParameterReadRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (n *NetworkParameterReadResponse) Validate() error {
+	if err := validateFrame(n.Header, n.MarshalLen(), TCDNetworkParameterReadRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(n.Header, n.Parameter.MarshalLen())
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (n *NetworkParameterWriteRequest) Validate() error {
+	if err := validateFrame(n.Header, n.MarshalLen(), TCDNetworkParameterWriteRequest, false); err != nil {
+		return err
+	}
+	return validateMSZ(n.Header, n.Parameter.MarshalLen())
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (n *NetworkParameterWriteResponse) Validate() error {
+	return validateFrame(n.Header, n.MarshalLen(), TCDNetworkParameterWriteRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (s *StopCommandRequest) Validate() error {
+	return validateFrame(s.Header, s.MarshalLen(), TCDStopCommandRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (s *StopCommandResponse) Validate() error {
+	return validateFrame(s.Header, s.MarshalLen(), TCDStopCommandRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (o *OperationCommandRequest) Validate() error {
+	return validateFrame(o.Header, o.MarshalLen(), TCDOperationCommandRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (o *OperationCommandResponse) Validate() error {
+	return validateFrame(o.Header, o.MarshalLen(), TCDOperationCommandRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (p *ProfileReadRequest) Validate() error {
+	return validateFrame(p.Header, p.MarshalLen(), TCDProfileReadRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (p *ProfileReadResponse) Validate() error {
+	if err := validateFrame(p.Header, p.MarshalLen(), TCDProfileReadRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(p.Header, p.Profile.MarshalLen())
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (t *TransparentMessage) Validate() error {
+	if err := t.Message.Validate(); err != nil {
+		return err
+	}
+	if t.Header.TCD > TCDTransparentMax {
+		return invalid("TCD", "got %d, want 0-%d", t.Header.TCD, TCDTransparentMax)
+	}
+	return validateMSZ(t.Header, len(t.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (l *LogDataReadRequest) Validate() error {
+	return validateFrame(l.Header, l.MarshalLen(), TCDLogDataReadRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (l *LogDataReadResponse) Validate() error {
+	if err := validateFrame(l.Header, l.MarshalLen(), TCDLogDataReadRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(l.Header, l.Log.MarshalLen())
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (l *LogDataClearRequest) Validate() error {
+	return validateFrame(l.Header, l.MarshalLen(), TCDLogDataClearRequest, false)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (l *LogDataClearResponse) Validate() error {
+	return validateFrame(l.Header, l.MarshalLen(), TCDLogDataClearRequest, true)
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (m *MessageReturnRequest) Validate() error {
+	if err := validateFrame(m.Header, m.MarshalLen(), TCDMessageReturnRequest, false); err != nil {
+		return err
+	}
+	return validateMSZ(m.Header, len(m.Data))
+}
+
+// Validate checks whether the frame conforms to the specification.
+func (m *MessageReturnResponse) Validate() error {
+	if err := validateFrame(m.Header, m.MarshalLen(), TCDMessageReturnRequest, true); err != nil {
+		return err
+	}
+	return validateMSZ(m.Header, len(m.Data))
+}
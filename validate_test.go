@@ -0,0 +1,232 @@
+// Copyright 2020 go-flnet authors. All rights reserved.
+// Use of this source code is governed by a MIT-style license that can be
+// found in the LICENSE file.
+
+package flnet_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kazukiigeta/go-flnet"
+)
+
+type validator interface {
+	flnet.FLnet
+	Validate() error
+}
+
+// newTestLastBlockToken returns a Token carrying the last block of
+// the cyclic data split by SplitCyclic.
+func newTestLastBlockToken() *flnet.Token {
+	frames, err := flnet.SplitCyclic(1, 2, 0, newTestImage(600, 1500))
+	if err != nil {
+		panic(err)
+	}
+	c := frames[len(frames)-1]
+	t := flnet.NewTokenWithCyclic(1, 2, 0, c.Header.CAD1, c.Header.CSZ1, c.Header.CAD2, c.Header.CSZ2, c.Data)
+	t.Header.CBN, t.Header.TBN, t.Header.TFL = c.Header.CBN, c.Header.TBN, c.Header.TFL
+	return t
+}
+
+func validFrames() []validator {
+	data := make([]byte, 136)
+	return []validator{
+		flnet.NewToken(),
+		flnet.NewTokenWithCyclic(1, 2, 0, 4, 4, 64, 64, data),
+		newTestLastBlockToken(),
+		flnet.NewCyclic(0x55, 0x01, 0, 4, 4, 64, 64, &data),
+		flnet.NewCyclic(0x55, 0x01, 0, 0, 0, 0, 0, nil),
+		flnet.NewTrigger(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."),
+		flnet.NewParticipationRequest(1, 255, 0, 0, "NODE", "VENDOR", "MANUF."),
+		flnet.NewByteBlockReadRequest(1, 2, 0, 1, 0x1000, 4),
+		flnet.NewByteBlockReadResponse(2, 1, 0, 1, 0x1000, []byte{1, 2, 3, 4}),
+		flnet.NewByteBlockWriteRequest(1, 2, 0, 1, 0x1000, []byte{1, 2}),
+		flnet.NewByteBlockWriteResponse(2, 1, 0, 1, 0x1000, 2),
+		flnet.NewWordBlockReadRequest(1, 2, 0, 1, 0x100, 2),
+		flnet.NewWordBlockReadResponse(2, 1, 0, 1, 0x100, []uint16{1, 2}),
+		flnet.NewWordBlockWriteRequest(1, 2, 0, 1, 0x100, []uint16{1}),
+		flnet.NewWordBlockWriteResponse(2, 1, 0, 1, 0x100, 1),
+		flnet.NewNetworkParameterReadRequest(1, 2, 0, 1),
+		flnet.NewNetworkParameterReadResponse(2, 1, 0, 1, &flnet.NetworkParameter{NodeName: "NODE"}),
+		flnet.NewNetworkParameterWriteRequest(1, 2, 0, 1, &flnet.NetworkParameter{NodeName: "NODE"}),
+		flnet.NewNetworkParameterWriteResponse(2, 1, 0, 1),
+		flnet.NewStopCommandRequest(1, 2, 0, 1),
+		flnet.NewStopCommandResponse(2, 1, 0, 1, flnet.ResultNormal),
+		flnet.NewOperationCommandRequest(1, 2, 0, 1),
+		flnet.NewOperationCommandResponse(2, 1, 0, 1, flnet.ResultNormal),
+		flnet.NewProfileReadRequest(1, 2, 0, 1),
+		newTestProfileReadResponse(2, 1, 0, 1),
+		flnet.NewTransparentMessage(1, 2, 0, 1, 1000, false, []byte{1}),
+		flnet.NewLogDataReadRequest(1, 2, 0, 1),
+		flnet.NewLogDataReadResponse(2, 1, 0, 1, &flnet.LogData{}),
+		flnet.NewLogDataClearRequest(1, 2, 0, 1),
+		flnet.NewLogDataClearResponse(2, 1, 0, 1),
+		flnet.NewMessageReturnRequest(1, 255, 0, 1, []byte{1}),
+		flnet.NewMessageReturnResponse(2, 1, 0, 1, []byte{1}),
+	}
+}
+
+func TestValidateValidFrames(t *testing.T) {
+	for _, f := range validFrames() {
+		if err := f.Validate(); err != nil {
+			t.Errorf("%T: %v", f, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		description string
+		frame       func() validator
+		field       string
+	}{
+		{
+			"wrong H_TYPE",
+			func() validator {
+				f := flnet.NewToken()
+				f.Header.HType = [4]byte{0x46, 0x41, 0x43, 0x4f}
+				return f
+			},
+			"H_TYPE",
+		},
+		{
+			"source node 0",
+			func() validator {
+				f := flnet.NewToken()
+				f.Header.SA = 0x00010000
+				return f
+			},
+			"SA",
+		},
+		{
+			"broadcast source node",
+			func() validator {
+				return flnet.NewTrigger(255, 255, 0, 0, "", "", "")
+			},
+			"SA",
+		},
+		{
+			"destination node 0",
+			func() validator {
+				return flnet.NewStopCommandRequest(1, 0, 0, 0)
+			},
+			"DA",
+		},
+		{
+			"wrong upper bits of DA",
+			func() validator {
+				f := flnet.NewToken()
+				f.Header.DA = 0x00020002
+				return f
+			},
+			"DA",
+		},
+		{
+			"area 1 overflow",
+			func() validator {
+				return flnet.NewCyclic(1, 2, 0, 500, 13, 0, 0, nil)
+			},
+			"C_SZ1",
+		},
+		{
+			"area 2 overflow",
+			func() validator {
+				return flnet.NewCyclic(1, 2, 0, 0, 0, 8000, 193, nil)
+			},
+			"C_SZ2",
+		},
+		{
+			"wrong TFL",
+			func() validator {
+				f := flnet.NewByteBlockWriteRequest(1, 2, 0, 0, 0, []byte{1, 2})
+				f.Header.TFL++
+				return f
+			},
+			"TFL",
+		},
+		{
+			"wrong BSIZE",
+			func() validator {
+				f := flnet.NewMessageReturnRequest(1, 2, 0, 0, []byte{1, 2})
+				f.Data = f.Data[:1]
+				return f
+			},
+			"BSIZE",
+		},
+		{
+			"cyclic data not matching C_SZ1 and C_SZ2",
+			func() validator {
+				data := make([]byte, 8)
+				return flnet.NewCyclic(1, 2, 0, 0, 4, 0, 4, &data)
+			},
+			"BSIZE",
+		},
+		{
+			"cyclic data not matching C_SZ1 and C_SZ2 in a token",
+			func() validator {
+				return flnet.NewTokenWithCyclic(1, 2, 0, 0, 4, 0, 4, make([]byte, 8))
+			},
+			"BSIZE",
+		},
+		{
+			"CBN larger than TBN",
+			func() validator {
+				f := flnet.NewToken()
+				f.Header.CBN = 2
+				return f
+			},
+			"CBN",
+		},
+		{
+			"TCD of another type",
+			func() validator {
+				f := flnet.NewStopCommandRequest(1, 2, 0, 0)
+				f.Header.TCD = flnet.TCDOperationCommandRequest
+				return f
+			},
+			"TCD",
+		},
+		{
+			"transparent message with reserved TCD",
+			func() validator {
+				return flnet.NewTransparentMessage(1, 2, 0, 0, 60000, false, nil)
+			},
+			"TCD",
+		},
+		{
+			"request with RPL",
+			func() validator {
+				f := flnet.NewByteBlockReadRequest(1, 2, 0, 0, 0, 1)
+				f.Header.SetRPL(true)
+				return f
+			},
+			"M_CTL",
+		},
+		{
+			"M_SZ not matching data",
+			func() validator {
+				f := flnet.NewWordBlockReadResponse(2, 1, 0, 0, 0, []uint16{1, 2})
+				f.Header.MSZ = 3
+				return f
+			},
+			"M_SZ",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.frame().Validate()
+			if !errors.Is(err, flnet.ErrInvalidFrame) {
+				t.Fatalf("got %v, want %v", err, flnet.ErrInvalidFrame)
+			}
+			var verr *flnet.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got %T, want *flnet.ValidationError", err)
+			}
+			if got, want := verr.Field, c.field; got != want {
+				t.Errorf("got %s (%v), want %s", got, err, want)
+			}
+		})
+	}
+}